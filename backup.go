@@ -2,11 +2,9 @@ package gorocksdb
 
 // #include <stdlib.h>
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
-import (
-	"errors"
-	"unsafe"
-)
+import "unsafe"
 
 // BackupEngineInfo represents the information about the backups
 // in a backup engine instance. Use this to get the state of the
@@ -41,12 +39,51 @@ func (b *BackupEngineInfo) GetNumFiles(index int) int32 {
 	return int32(C.rocksdb_backup_engine_info_number_files(b.c, C.int(index)))
 }
 
+// GetAppMetadata gets the application metadata stored with the backup
+// index, see BackupEngine.CreateNewBackupWithMetadata.
+func (b *BackupEngineInfo) GetAppMetadata(index int) string {
+	var cLen C.size_t
+	cMetadata := C.gorocksdb_backup_engine_info_app_metadata(b.c, C.int(index), &cLen)
+	return C.GoStringN(cMetadata, C.int(cLen))
+}
+
+// Backups returns the information about all backups, ordered from the
+// oldest to the latest backup.
+func (b *BackupEngineInfo) Backups() []BackupInfo {
+	backups := make([]BackupInfo, b.GetCount())
+	for i := range backups {
+		backups[i] = BackupInfo{
+			ID:          uint32(b.GetBackupID(i)),
+			Timestamp:   b.GetTimestamp(i),
+			Size:        b.GetSize(i),
+			NumFiles:    b.GetNumFiles(i),
+			AppMetadata: b.GetAppMetadata(i),
+		}
+	}
+	return backups
+}
+
 // Destroy destroys the backup engine info instance.
 func (b *BackupEngineInfo) Destroy() {
 	C.rocksdb_backup_engine_info_destroy(b.c)
 	b.c = nil
 }
 
+// BackupInfo describes a single backup.
+type BackupInfo struct {
+	// ID uniquely identifies the backup regardless of its position.
+	ID uint32
+	// Timestamp is the time at which the backup was taken, in seconds since
+	// the Unix epoch.
+	Timestamp int64
+	// Size is the size of the backup in bytes.
+	Size int64
+	// NumFiles is the number of files in the backup.
+	NumFiles int32
+	// AppMetadata is the application metadata stored with the backup.
+	AppMetadata string
+}
+
 // RestoreOptions captures the options to be used during
 // restoration of a backup.
 type RestoreOptions struct {
@@ -75,7 +112,10 @@ func (ro *RestoreOptions) Destroy() {
 }
 
 // BackupEngine is a reusable handle to a RocksDB Backup, created by
-// OpenBackupEngine.
+// OpenBackupEngine or OpenBackupEngineWithOptions.
+//
+// To back up a TransactionDB, pass the DB returned by
+// TransactionDB.GetBaseDb to the backup methods.
 type BackupEngine struct {
 	c    *C.rocksdb_backup_engine_t
 	path string
@@ -91,7 +131,7 @@ func OpenBackupEngine(opts *Options, path string) (*BackupEngine, error) {
 	be := C.rocksdb_backup_engine_open(opts.c, cpath, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return &BackupEngine{
 		c:    be,
@@ -100,6 +140,25 @@ func OpenBackupEngine(opts *Options, path string) (*BackupEngine, error) {
 	}, nil
 }
 
+// OpenBackupEngineWithOptions opens a backup engine with the specified
+// backup options. The backups are stored in the backup directory of
+// backupOpts; opts only provides the environment of the database.
+// backupOpts can be destroyed after the call.
+func OpenBackupEngineWithOptions(opts *Options, backupOpts *BackupEngineOptions) (*BackupEngine, error) {
+	var cErr *C.char
+
+	be := C.gorocksdb_backup_engine_open_opts(opts.c, backupOpts.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return &BackupEngine{
+		c:    be,
+		path: C.GoString(C.gorocksdb_backup_engine_options_get_backup_dir(backupOpts.c)),
+		opts: opts,
+	}, nil
+}
+
 // UnsafeGetBackupEngine returns the underlying c backup engine.
 func (b *BackupEngine) UnsafeGetBackupEngine() unsafe.Pointer {
 	return unsafe.Pointer(b.c)
@@ -112,7 +171,43 @@ func (b *BackupEngine) CreateNewBackup(db *DB) error {
 	C.rocksdb_backup_engine_create_new_backup(b.c, db.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
+	}
+
+	return nil
+}
+
+// CreateNewBackupFlush takes a new backup from db. If flushBeforeBackup is
+// true, the memtables are flushed first, so the backup does not need to
+// include the write ahead log.
+func (b *BackupEngine) CreateNewBackupFlush(db *DB, flushBeforeBackup bool) error {
+	var cErr *C.char
+
+	C.rocksdb_backup_engine_create_new_backup_flush(b.c, db.c, boolToChar(flushBeforeBackup), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
+	}
+
+	return nil
+}
+
+// CreateNewBackupWithMetadata takes a new backup from db and stores the
+// application metadata with it. The metadata is returned by
+// BackupEngineInfo.GetAppMetadata and BackupInfo.AppMetadata.
+// If flushBeforeBackup is true, the memtables are flushed first.
+func (b *BackupEngine) CreateNewBackupWithMetadata(db *DB, metadata string, flushBeforeBackup bool) error {
+	var (
+		cErr      *C.char
+		cMetadata = C.CString(metadata)
+	)
+	defer C.free(unsafe.Pointer(cMetadata))
+
+	C.gorocksdb_backup_engine_create_new_backup_with_metadata(
+		b.c, db.c, cMetadata, C.size_t(len(metadata)), boolToChar(flushBeforeBackup), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
 	}
 
 	return nil
@@ -126,6 +221,14 @@ func (b *BackupEngine) GetInfo() *BackupEngineInfo {
 	}
 }
 
+// GetBackupInfo returns the information about the backups that have
+// already been taken, ordered from the oldest to the latest backup.
+func (b *BackupEngine) GetBackupInfo() []BackupInfo {
+	info := b.GetInfo()
+	defer info.Destroy()
+	return info.Backups()
+}
+
 // RestoreDBFromLatestBackup restores the latest backup to dbDir. walDir
 // is where the write ahead logs are restored to and usually the same as dbDir.
 func (b *BackupEngine) RestoreDBFromLatestBackup(dbDir, walDir string, ro *RestoreOptions) error {
@@ -140,7 +243,26 @@ func (b *BackupEngine) RestoreDBFromLatestBackup(dbDir, walDir string, ro *Resto
 	C.rocksdb_backup_engine_restore_db_from_latest_backup(b.c, cDbDir, cWalDir, ro.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// RestoreDBFromBackup restores the backup with the given id to dbDir. walDir
+// is where the write ahead logs are restored to and usually the same as dbDir.
+func (b *BackupEngine) RestoreDBFromBackup(backupID uint32, dbDir, walDir string, ro *RestoreOptions) error {
+	var cErr *C.char
+	cDbDir := C.CString(dbDir)
+	cWalDir := C.CString(walDir)
+	defer func() {
+		C.free(unsafe.Pointer(cDbDir))
+		C.free(unsafe.Pointer(cWalDir))
+	}()
+
+	C.rocksdb_backup_engine_restore_db_from_backup(b.c, cDbDir, cWalDir, ro.c, C.uint32_t(backupID), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -153,7 +275,7 @@ func (b *BackupEngine) VerifyBackup(id uint32) error {
 	C.rocksdb_backup_engine_verify_backup(b.c, C.uint32_t(id), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -164,7 +286,18 @@ func (b *BackupEngine) PurgeOldBackups(numBackupsToKeep uint32) error {
 	C.rocksdb_backup_engine_purge_old_backups(b.c, C.uint32_t(numBackupsToKeep), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// DeleteBackup deletes the backup with the given id.
+func (b *BackupEngine) DeleteBackup(backupID uint32) error {
+	var cErr *C.char
+	C.gorocksdb_backup_engine_delete_backup(b.c, C.uint32_t(backupID), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
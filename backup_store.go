@@ -0,0 +1,190 @@
+package gorocksdb
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BackupStore stores the objects of the backups taken by a
+// StoreBackupEngine, for example in an object storage. Object names are
+// slash separated paths like "shared/000012_4096.sst".
+//
+// Get must return an error for which os.IsNotExist returns true if the
+// object does not exist.
+type BackupStore interface {
+	// Put stores the content of r as the object name, replacing an existing
+	// object with the same name.
+	Put(name string, r io.Reader) error
+	// Get opens the object name for reading.
+	Get(name string) (io.ReadCloser, error)
+	// Exists reports whether the object name exists.
+	Exists(name string) (bool, error)
+	// List returns the sorted names of all objects starting with prefix.
+	List(prefix string) ([]string, error)
+	// Delete deletes the object name. Deleting a missing object is not an
+	// error.
+	Delete(name string) error
+}
+
+// FSBackupStore is a BackupStore which stores the objects as files below a
+// directory.
+type FSBackupStore struct {
+	dir string
+}
+
+// NewFSBackupStore creates a BackupStore storing the objects below dir. The
+// directory is created if it does not exist.
+func NewFSBackupStore(dir string) (*FSBackupStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FSBackupStore{dir: dir}, nil
+}
+
+func (s *FSBackupStore) path(name string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(name))
+}
+
+// Put implements the BackupStore interface. The object is written to a
+// temporary file first, so a failed Put never leaves a partial object.
+func (s *FSBackupStore) Put(name string, r io.Reader) error {
+	path := s.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// Get implements the BackupStore interface.
+func (s *FSBackupStore) Get(name string) (io.ReadCloser, error) {
+	return os.Open(s.path(name))
+}
+
+// Exists implements the BackupStore interface.
+func (s *FSBackupStore) Exists(name string) (bool, error) {
+	_, err := os.Stat(s.path(name))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// List implements the BackupStore interface.
+func (s *FSBackupStore) List(prefix string) ([]string, error) {
+	var names []string
+	err := filepath.Walk(s.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		if name := filepath.ToSlash(rel); strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	sort.Strings(names)
+	return names, err
+}
+
+// Delete implements the BackupStore interface.
+func (s *FSBackupStore) Delete(name string) error {
+	err := os.Remove(s.path(name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// MemBackupStore is a BackupStore which keeps the objects in memory. It is
+// meant for tests.
+type MemBackupStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+// NewMemBackupStore creates an empty in-memory BackupStore.
+func NewMemBackupStore() *MemBackupStore {
+	return &MemBackupStore{objects: make(map[string][]byte)}
+}
+
+// Put implements the BackupStore interface.
+func (s *MemBackupStore) Put(name string, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.objects[name] = data
+	s.mu.Unlock()
+	return nil
+}
+
+// Get implements the BackupStore interface.
+func (s *MemBackupStore) Get(name string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	data, ok := s.objects[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, &os.PathError{Op: "get", Path: name, Err: os.ErrNotExist}
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Exists implements the BackupStore interface.
+func (s *MemBackupStore) Exists(name string) (bool, error) {
+	s.mu.Lock()
+	_, ok := s.objects[name]
+	s.mu.Unlock()
+	return ok, nil
+}
+
+// List implements the BackupStore interface.
+func (s *MemBackupStore) List(prefix string) ([]string, error) {
+	s.mu.Lock()
+	var names []string
+	for name := range s.objects {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	s.mu.Unlock()
+	sort.Strings(names)
+	return names, nil
+}
+
+// Delete implements the BackupStore interface.
+func (s *MemBackupStore) Delete(name string) error {
+	s.mu.Lock()
+	delete(s.objects, name)
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,338 @@
+package gorocksdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	backupStoreMetaPrefix    = "meta/"
+	backupStoreSharedPrefix  = "shared/"
+	backupStorePrivatePrefix = "private/"
+)
+
+// StoreBackupEngine takes incremental backups of a database into a
+// BackupStore. A backup is a checkpoint of the database: its table files are
+// stored once and shared by all backups containing them, the other files,
+// like the MANIFEST and the write ahead logs, are stored per backup. A
+// manifest describes the files of each backup.
+//
+// Table files are identified by their name, size and checksum, so a store
+// must only hold the backups of a single database. A StoreBackupEngine must not be
+// used concurrently with another one on the same store.
+type StoreBackupEngine struct {
+	store BackupStore
+}
+
+// BackupManifest describes a backup taken by a StoreBackupEngine.
+type BackupManifest struct {
+	ID        uint32       `json:"id"`
+	Timestamp int64        `json:"timestamp"`
+	Files     []BackupFile `json:"files"`
+}
+
+// BackupFile describes a file of a backup.
+type BackupFile struct {
+	// Name is the name of the file in the database directory.
+	Name string `json:"name"`
+	// Object is the name of the object holding the file in the store.
+	Object string `json:"object"`
+	Size   int64  `json:"size"`
+	// Checksum is the CRC32C checksum of the file, which is verified on
+	// restore.
+	Checksum uint32 `json:"checksum"`
+	// Level is the level of a table file, or -1 for the other files and
+	// table files which were compacted between the checkpoint and reading
+	// their metadata.
+	Level       int    `json:"level"`
+	SmallestKey []byte `json:"smallest_key,omitempty"`
+	LargestKey  []byte `json:"largest_key,omitempty"`
+}
+
+// NewStoreBackupEngine creates a StoreBackupEngine storing the backups in
+// store.
+func NewStoreBackupEngine(store BackupStore) *StoreBackupEngine {
+	return &StoreBackupEngine{store: store}
+}
+
+// CreateBackup takes a new backup of db. Only the table files which are not
+// yet in the store are uploaded.
+//
+// The checkpoint of the database is created in a temporary directory next to
+// the database directory, so the table files are hard-linked and not copied.
+// File deletions are disabled while the backup is taken.
+func (e *StoreBackupEngine) CreateBackup(db *DB) (*BackupManifest, error) {
+	backups, err := e.GetBackups()
+	if err != nil {
+		return nil, err
+	}
+	manifest := &BackupManifest{ID: 1, Timestamp: time.Now().Unix()}
+	if len(backups) > 0 {
+		manifest.ID = backups[len(backups)-1].ID + 1
+	}
+
+	if err := db.DisableFileDeletions(); err != nil {
+		return nil, err
+	}
+	defer db.EnableFileDeletions(false)
+
+	tmpDir, err := ioutil.TempDir(filepath.Dir(filepath.Clean(db.Name())), ".gorocksdb-backup-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	checkpointDir := filepath.Join(tmpDir, "checkpoint")
+
+	checkpoint, err := db.NewCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+	err = checkpoint.CreateCheckpoint(checkpointDir, 0)
+	checkpoint.Destroy()
+	if err != nil {
+		return nil, err
+	}
+
+	// the checkpoint flushes the memtable, so the metadata is read afterwards
+	// to include the flushed table file
+	liveFiles := make(map[string]LiveFileMetadata)
+	for _, lf := range db.GetLiveFilesMetaData() {
+		liveFiles[strings.TrimPrefix(lf.Name, "/")] = lf
+	}
+
+	infos, err := ioutil.ReadDir(checkpointDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		file := BackupFile{Name: info.Name(), Size: info.Size(), Level: -1}
+		file.Checksum, err = fileChecksum(filepath.Join(checkpointDir, file.Name))
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(file.Name, ".sst") {
+			file.Object = backupStoreSharedObject(file.Name, file.Size, file.Checksum)
+			if lf, ok := liveFiles[file.Name]; ok {
+				file.Level = lf.Level
+				file.SmallestKey = lf.SmallestKey
+				file.LargestKey = lf.LargestKey
+			}
+			exists, err := e.store.Exists(file.Object)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				manifest.Files = append(manifest.Files, file)
+				continue
+			}
+		} else {
+			file.Object = fmt.Sprintf("%s%d/%s", backupStorePrivatePrefix, manifest.ID, file.Name)
+		}
+		if err := e.putFile(file.Object, filepath.Join(checkpointDir, file.Name)); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	// the manifest is stored last, so a failed backup is never listed
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.store.Put(backupStoreMetaObject(manifest.ID), bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (e *StoreBackupEngine) putFile(object, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return e.store.Put(object, f)
+}
+
+// GetBackups returns the manifests of all backups, ordered by their id.
+func (e *StoreBackupEngine) GetBackups() ([]*BackupManifest, error) {
+	names, err := e.store.List(backupStoreMetaPrefix)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]*BackupManifest, 0, len(names))
+	for _, name := range names {
+		id, err := strconv.ParseUint(strings.TrimPrefix(name, backupStoreMetaPrefix), 10, 32)
+		if err != nil {
+			// not a manifest
+			continue
+		}
+		manifest, err := e.GetBackup(uint32(id))
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, manifest)
+	}
+	return backups, nil
+}
+
+// GetBackup returns the manifest of the backup with the given id.
+func (e *StoreBackupEngine) GetBackup(backupID uint32) (*BackupManifest, error) {
+	r, err := e.store.Get(backupStoreMetaObject(backupID))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	manifest := &BackupManifest{}
+	if err := json.NewDecoder(r).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("backup %d: invalid manifest: %v", backupID, err)
+	}
+	return manifest, nil
+}
+
+// RestoreBackup restores the backup with the given id into dir, which can
+// then be opened with OpenDb. The directory must not exist or be empty.
+//
+// The files are restored into a temporary directory next to dir, which is
+// renamed to dir once all files are restored, so a failed restore leaves dir
+// unchanged and can be retried.
+func (e *StoreBackupEngine) RestoreBackup(backupID uint32, dir string) error {
+	manifest, err := e.GetBackup(backupID)
+	if err != nil {
+		return err
+	}
+	dir = filepath.Clean(dir)
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(infos) > 0 {
+		return fmt.Errorf("restore directory %s is not empty", dir)
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	tmpDir, err := ioutil.TempDir(filepath.Dir(dir), "."+filepath.Base(dir)+"-restore-")
+	if err != nil {
+		return err
+	}
+	if err := e.restoreFiles(manifest, tmpDir, dir); err != nil {
+		os.RemoveAll(tmpDir)
+		return err
+	}
+	return nil
+}
+
+func (e *StoreBackupEngine) restoreFiles(manifest *BackupManifest, tmpDir, dir string) error {
+	for _, file := range manifest.Files {
+		if err := e.restoreFile(file, filepath.Join(tmpDir, file.Name)); err != nil {
+			return err
+		}
+	}
+	if err := os.Chmod(tmpDir, 0755); err != nil {
+		return err
+	}
+	// dir is empty if it exists, the rename fails if it was filled meanwhile
+	if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Rename(tmpDir, dir)
+}
+
+func (e *StoreBackupEngine) restoreFile(file BackupFile, filename string) error {
+	r, err := e.store.Get(file.Object)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	checksum := crc32.New(backupStoreCRC32CTable)
+	n, err := io.Copy(io.MultiWriter(f, checksum), r)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n != file.Size {
+		err = fmt.Errorf("%s: restored %d bytes, expected %d", file.Object, n, file.Size)
+	}
+	if err == nil && checksum.Sum32() != file.Checksum {
+		err = fmt.Errorf("%s: checksum mismatch", file.Object)
+	}
+	return err
+}
+
+var backupStoreCRC32CTable = crc32.MakeTable(crc32.Castagnoli)
+
+func fileChecksum(filename string) (uint32, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	checksum := crc32.New(backupStoreCRC32CTable)
+	if _, err := io.Copy(checksum, f); err != nil {
+		return 0, err
+	}
+	return checksum.Sum32(), nil
+}
+
+// DeleteBackup deletes the backup with the given id, including the table
+// files which are not part of another backup.
+func (e *StoreBackupEngine) DeleteBackup(backupID uint32) error {
+	manifest, err := e.GetBackup(backupID)
+	if err != nil {
+		return err
+	}
+	// delete the manifest first, so a partially deleted backup is never
+	// listed
+	if err := e.store.Delete(backupStoreMetaObject(backupID)); err != nil {
+		return err
+	}
+	backups, err := e.GetBackups()
+	if err != nil {
+		return err
+	}
+	used := make(map[string]bool)
+	for _, backup := range backups {
+		for _, file := range backup.Files {
+			used[file.Object] = true
+		}
+	}
+	for _, file := range manifest.Files {
+		if used[file.Object] {
+			continue
+		}
+		if err := e.store.Delete(file.Object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func backupStoreMetaObject(backupID uint32) string {
+	// zero-padded, so the manifests are listed in the order of their ids
+	return fmt.Sprintf("%s%010d", backupStoreMetaPrefix, backupID)
+}
+
+func backupStoreSharedObject(name string, size int64, checksum uint32) string {
+	ext := path.Ext(name)
+	return fmt.Sprintf("%s%s_%d_%08x%s", backupStoreSharedPrefix, strings.TrimSuffix(name, ext), size, checksum, ext)
+}
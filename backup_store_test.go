@@ -0,0 +1,178 @@
+package gorocksdb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func testBackupStore(t *testing.T, store BackupStore) {
+	ensure.Nil(t, store.Put("shared/1.sst", strings.NewReader("one")))
+	ensure.Nil(t, store.Put("shared/2.sst", strings.NewReader("two")))
+	ensure.Nil(t, store.Put("meta/1", strings.NewReader("meta")))
+	ensure.Nil(t, store.Put("shared/1.sst", strings.NewReader("uno")))
+
+	r, err := store.Get("shared/1.sst")
+	ensure.Nil(t, err)
+	data, err := ioutil.ReadAll(r)
+	ensure.Nil(t, err)
+	ensure.Nil(t, r.Close())
+	ensure.DeepEqual(t, string(data), "uno")
+
+	exists, err := store.Exists("shared/2.sst")
+	ensure.Nil(t, err)
+	ensure.True(t, exists)
+
+	names, err := store.List("shared/")
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, names, []string{"shared/1.sst", "shared/2.sst"})
+
+	ensure.Nil(t, store.Delete("shared/2.sst"))
+	ensure.Nil(t, store.Delete("shared/2.sst"))
+	exists, err = store.Exists("shared/2.sst")
+	ensure.Nil(t, err)
+	ensure.False(t, exists)
+	_, err = store.Get("shared/2.sst")
+	ensure.True(t, os.IsNotExist(err))
+}
+
+func TestMemBackupStore(t *testing.T) {
+	testBackupStore(t, NewMemBackupStore())
+}
+
+func TestFSBackupStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestFSBackupStore")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewFSBackupStore(dir)
+	ensure.Nil(t, err)
+	testBackupStore(t, store)
+}
+
+func TestStoreBackupEngine(t *testing.T) {
+	db := newTestDB(t, "TestStoreBackupEngine", nil)
+	defer db.Close()
+	store := NewMemBackupStore()
+	engine := NewStoreBackupEngine(store)
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("v1")))
+	first, err := engine.CreateBackup(db)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, first.ID, uint32(1))
+	var tableFiles []BackupFile
+	for _, file := range first.Files {
+		if strings.HasSuffix(file.Name, ".sst") {
+			tableFiles = append(tableFiles, file)
+		}
+	}
+	// key1 was flushed by the backup
+	ensure.DeepEqual(t, len(tableFiles), 1)
+	ensure.DeepEqual(t, tableFiles[0].Level, 0)
+	ensure.DeepEqual(t, tableFiles[0].SmallestKey, []byte("key1"))
+	ensure.DeepEqual(t, tableFiles[0].LargestKey, []byte("key1"))
+	shared, err := store.List(backupStoreSharedPrefix)
+	ensure.Nil(t, err)
+	ensure.True(t, len(shared) > 0)
+
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("v2")))
+	second, err := engine.CreateBackup(db)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, second.ID, uint32(2))
+
+	// the table files of the first backup are shared with the second one
+	sharedAfter, err := store.List(backupStoreSharedPrefix)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(sharedAfter), len(shared)+1)
+
+	backups, err := engine.GetBackups()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(backups), 2)
+	ensure.DeepEqual(t, backups[1].ID, second.ID)
+
+	restore := func(backupID uint32, expectKey2 bool) {
+		dir, err := ioutil.TempDir("", "gorocksdb-TestStoreBackupEngine-restore")
+		ensure.Nil(t, err)
+		defer os.RemoveAll(dir)
+		ensure.Nil(t, engine.RestoreBackup(backupID, dir))
+
+		restored, err := OpenDb(NewDefaultOptions(), dir)
+		ensure.Nil(t, err)
+		defer restored.Close()
+		ro := NewDefaultReadOptions()
+		v1, err := restored.GetBytes(ro, []byte("key1"))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, v1, []byte("v1"))
+		v2, err := restored.GetBytes(ro, []byte("key2"))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, v2 != nil, expectKey2)
+	}
+	restore(first.ID, false)
+	restore(second.ID, true)
+
+	// deleting the first backup keeps the files of the second one
+	ensure.Nil(t, engine.DeleteBackup(first.ID))
+	backups, err = engine.GetBackups()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(backups), 1)
+	restore(second.ID, true)
+	_, err = engine.GetBackup(first.ID)
+	ensure.True(t, os.IsNotExist(err))
+}
+
+func TestStoreBackupEngineRestoreFailure(t *testing.T) {
+	db := newTestDB(t, "TestStoreBackupEngineRestoreFailure", nil)
+	defer db.Close()
+	store := NewMemBackupStore()
+	engine := NewStoreBackupEngine(store)
+
+	ensure.Nil(t, db.Put(NewDefaultWriteOptions(), []byte("key1"), []byte("v1")))
+	manifest, err := engine.CreateBackup(db)
+	ensure.Nil(t, err)
+	var tableFile BackupFile
+	for _, file := range manifest.Files {
+		if strings.HasSuffix(file.Name, ".sst") {
+			tableFile = file
+		}
+	}
+	ensure.True(t, strings.Contains(tableFile.Object, fmt.Sprintf("_%08x", tableFile.Checksum)))
+
+	// corrupt the table file without changing its size
+	r, err := store.Get(tableFile.Object)
+	ensure.Nil(t, err)
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	ensure.Nil(t, err)
+	corrupted := append([]byte(nil), data...)
+	corrupted[0] ^= 0xff
+	ensure.Nil(t, store.Put(tableFile.Object, bytes.NewReader(corrupted)))
+
+	parent, err := ioutil.TempDir("", "gorocksdb-TestStoreBackupEngineRestoreFailure")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(parent)
+	dir := filepath.Join(parent, "restore")
+	err = engine.RestoreBackup(manifest.ID, dir)
+	ensure.NotNil(t, err)
+	ensure.True(t, strings.Contains(err.Error(), "checksum mismatch"))
+
+	// nothing is left behind, so the restore can be retried
+	infos, err := ioutil.ReadDir(parent)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(infos), 0)
+	ensure.Nil(t, store.Put(tableFile.Object, bytes.NewReader(data)))
+	ensure.Nil(t, engine.RestoreBackup(manifest.ID, dir))
+
+	restored, err := OpenDb(NewDefaultOptions(), dir)
+	ensure.Nil(t, err)
+	defer restored.Close()
+	v1, err := restored.GetBytes(NewDefaultReadOptions(), []byte("key1"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1, []byte("v1"))
+}
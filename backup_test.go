@@ -0,0 +1,132 @@
+package gorocksdb
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func newTestBackupEngine(t *testing.T, name string) *BackupEngine {
+	dir, err := ioutil.TempDir("", "gorocksdb-backup-"+name)
+	ensure.Nil(t, err)
+
+	be, err := OpenBackupEngine(NewDefaultOptions(), dir)
+	ensure.Nil(t, err)
+
+	return be
+}
+
+func TestBackupEngineMetadataAndRestoreByID(t *testing.T) {
+	db := newTestDB(t, "TestBackupEngineMetadataAndRestoreByID", nil)
+	defer db.Close()
+	be := newTestBackupEngine(t, "TestBackupEngineMetadataAndRestoreByID")
+	defer be.Close()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("key"), []byte("v1")))
+	ensure.Nil(t, be.CreateNewBackupWithMetadata(db, "first", true))
+	ensure.Nil(t, db.Put(wo, []byte("key"), []byte("v2")))
+	ensure.Nil(t, be.CreateNewBackupFlush(db, true))
+
+	backups := be.GetBackupInfo()
+	ensure.DeepEqual(t, len(backups), 2)
+	ensure.DeepEqual(t, backups[0].AppMetadata, "first")
+	ensure.DeepEqual(t, backups[1].AppMetadata, "")
+	ensure.True(t, backups[0].ID < backups[1].ID)
+	ensure.True(t, backups[0].NumFiles > 0)
+
+	// restore the first backup
+	restoreDir, err := ioutil.TempDir("", "gorocksdb-restore")
+	ensure.Nil(t, err)
+	ro := NewRestoreOptions()
+	defer ro.Destroy()
+	ensure.Nil(t, be.RestoreDBFromBackup(backups[0].ID, restoreDir, restoreDir, ro))
+
+	restored, err := OpenDb(NewDefaultOptions(), restoreDir)
+	ensure.Nil(t, err)
+	defer restored.Close()
+	value, err := restored.Get(NewDefaultReadOptions(), []byte("key"))
+	ensure.Nil(t, err)
+	defer value.Free()
+	ensure.DeepEqual(t, value.Data(), []byte("v1"))
+
+	// delete the first backup
+	ensure.Nil(t, be.DeleteBackup(backups[0].ID))
+	remaining := be.GetBackupInfo()
+	ensure.DeepEqual(t, len(remaining), 1)
+	ensure.DeepEqual(t, remaining[0].ID, backups[1].ID)
+	ensure.NotNil(t, be.RestoreDBFromBackup(backups[0].ID, restoreDir, restoreDir, ro))
+}
+
+func TestBackupEngineTransactionDB(t *testing.T) {
+	db := newTestTransactionDB(t, "TestBackupEngineTransactionDB", nil)
+	defer db.Close()
+	be := newTestBackupEngine(t, "TestBackupEngineTransactionDB")
+	defer be.Close()
+
+	ensure.Nil(t, db.Put(NewDefaultWriteOptions(), []byte("key"), []byte("value")))
+
+	baseDb := db.GetBaseDb()
+	ensure.Nil(t, be.CreateNewBackupWithMetadata(baseDb, "txn", true))
+	db.CloseBaseDb(baseDb)
+
+	backups := be.GetBackupInfo()
+	ensure.DeepEqual(t, len(backups), 1)
+	ensure.DeepEqual(t, backups[0].AppMetadata, "txn")
+
+	restoreDir, err := ioutil.TempDir("", "gorocksdb-restore")
+	ensure.Nil(t, err)
+	ro := NewRestoreOptions()
+	defer ro.Destroy()
+	ensure.Nil(t, be.RestoreDBFromLatestBackup(restoreDir, restoreDir, ro))
+
+	restored, err := OpenDb(NewDefaultOptions(), restoreDir)
+	ensure.Nil(t, err)
+	defer restored.Close()
+	value, err := restored.Get(NewDefaultReadOptions(), []byte("key"))
+	ensure.Nil(t, err)
+	defer value.Free()
+	ensure.DeepEqual(t, value.Data(), []byte("value"))
+}
+
+func TestBackupEngineWithOptions(t *testing.T) {
+	db := newTestDB(t, "TestBackupEngineWithOptions", nil)
+	defer db.Close()
+
+	dir, err := ioutil.TempDir("", "gorocksdb-backup-TestBackupEngineWithOptions")
+	ensure.Nil(t, err)
+
+	rateLimiter := NewRateLimiter(64<<20, 100*1000, 10)
+	defer rateLimiter.Destroy()
+	backupOpts := NewDefaultBackupEngineOptions(dir)
+	backupOpts.SetShareTableFiles(true)
+	backupOpts.SetShareFilesWithChecksum(true)
+	backupOpts.SetSync(false)
+	backupOpts.SetBackupRateLimit(64 << 20)
+	backupOpts.SetRestoreRateLimiter(rateLimiter)
+	backupOpts.SetMaxBackgroundOperations(2)
+	backupOpts.SetCallbackTriggerIntervalSize(1 << 20)
+	be, err := OpenBackupEngineWithOptions(NewDefaultOptions(), backupOpts)
+	backupOpts.Destroy()
+	ensure.Nil(t, err)
+	defer be.Close()
+
+	ensure.Nil(t, db.Put(NewDefaultWriteOptions(), []byte("key"), []byte("value")))
+	ensure.Nil(t, be.CreateNewBackupFlush(db, true))
+	ensure.DeepEqual(t, len(be.GetBackupInfo()), 1)
+
+	restoreDir, err := ioutil.TempDir("", "gorocksdb-restore")
+	ensure.Nil(t, err)
+	ro := NewRestoreOptions()
+	defer ro.Destroy()
+	ensure.Nil(t, be.RestoreDBFromLatestBackup(restoreDir, restoreDir, ro))
+
+	restored, err := OpenDb(NewDefaultOptions(), restoreDir)
+	ensure.Nil(t, err)
+	defer restored.Close()
+	value, err := restored.Get(NewDefaultReadOptions(), []byte("key"))
+	ensure.Nil(t, err)
+	defer value.Free()
+	ensure.DeepEqual(t, value.Data(), []byte("value"))
+}
@@ -0,0 +1,251 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultBulkLoadTargetFileSize is the default size at which a BulkLoader
+// starts a new sst file.
+const DefaultBulkLoadTargetFileSize = 64 << 20
+
+// errBulkLoadAborted is returned to the writers of the other partitions once
+// a partition failed.
+var errBulkLoadAborted = errors.New("bulk load aborted")
+
+// BulkLoadPartition is a part of the data loaded by a BulkLoader.
+type BulkLoadPartition struct {
+	// Start and Limit bound the keys of the partition: Start <= key < Limit.
+	// A nil Start or Limit leaves the partition unbounded on that side.
+	Start []byte
+	Limit []byte
+	// Write writes the entries of the partition by calling add with the keys
+	// in ascending order. The key and value can be reused once add returns.
+	// Write must return the error of add, if any.
+	Write func(add func(key, value []byte) error) error
+}
+
+// BulkLoader loads sorted data into a database by writing it to sst files
+// and ingesting them. Partitions are written in parallel, each with its own
+// SSTFileWriter. A new sst file is started once a file reaches the target
+// file size.
+//
+// The order of the keys is validated with the comparator of the column family
+// the data is loaded into. The sst files are written with the options passed
+// to NewBulkLoader, which must use the same comparator, otherwise the
+// ingestion fails.
+type BulkLoader struct {
+	db             *DB
+	cf             *ColumnFamilyHandle
+	opts           *Options
+	dir            string
+	targetFileSize uint64
+	parallelism    int
+}
+
+// NewBulkLoader creates a BulkLoader loading into the column family cf of db,
+// or into the default column family if cf is nil. The sst files are written
+// into dir, which should be on the same filesystem as the database.
+//
+// opts are the options of the column family. If cf is nil, opts can be nil to
+// use the options the database was opened with.
+func NewBulkLoader(db *DB, cf *ColumnFamilyHandle, opts *Options, dir string) *BulkLoader {
+	if opts == nil && cf == nil {
+		opts = db.opts
+	}
+	return &BulkLoader{
+		db:             db,
+		cf:             cf,
+		opts:           opts,
+		dir:            dir,
+		targetFileSize: DefaultBulkLoadTargetFileSize,
+		parallelism:    runtime.NumCPU(),
+	}
+}
+
+// SetTargetFileSize sets the size at which a new sst file is started.
+// Default: 64MB
+func (b *BulkLoader) SetTargetFileSize(size uint64) {
+	b.targetFileSize = size
+}
+
+// SetParallelism sets the maximum number of partitions written at the same
+// time.
+// Default: the number of CPUs
+func (b *BulkLoader) SetParallelism(n int) {
+	if n < 1 {
+		n = 1
+	}
+	b.parallelism = n
+}
+
+// Load writes the partitions to sst files in parallel and ingests all files
+// at once with ingestOpts. The key ranges of the partitions must not overlap.
+// The sst files are removed once they are ingested or the load failed. Load
+// must not be called concurrently with the same directory.
+func (b *BulkLoader) Load(ingestOpts *IngestExternalFileOptions, partitions ...BulkLoadPartition) error {
+	if b.opts == nil {
+		return errors.New("bulk load into a column family requires its options")
+	}
+	if err := b.checkPartitions(partitions); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(b.dir, 0755); err != nil {
+		return err
+	}
+
+	var (
+		wg      sync.WaitGroup
+		failed  int32
+		sem     = make(chan struct{}, b.parallelism)
+		errs    = make([]error, len(partitions))
+		files   = make([][]string, len(partitions))
+		aborted = func() bool {
+			return atomic.LoadInt32(&failed) != 0
+		}
+	)
+	for i := range partitions {
+		sem <- struct{}{}
+		if aborted() {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			files[i], errs[i] = b.writePartition(i, partitions[i], aborted)
+			if errs[i] != nil {
+				atomic.StoreInt32(&failed, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f...)
+	}
+	defer func() {
+		for _, path := range paths {
+			os.Remove(path)
+		}
+	}()
+	for _, err := range errs {
+		if err != nil && err != errBulkLoadAborted {
+			return err
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if b.cf == nil {
+		return b.db.IngestExternalFile(paths, ingestOpts)
+	}
+	return b.db.IngestExternalFileCF(b.cf, paths, ingestOpts)
+}
+
+// checkPartitions checks that the partitions are ordered by their key ranges
+// and do not overlap.
+func (b *BulkLoader) checkPartitions(partitions []BulkLoadPartition) error {
+	for i, p := range partitions {
+		if p.Start != nil && p.Limit != nil && b.compare(p.Start, p.Limit) >= 0 {
+			return fmt.Errorf("bulk load partition %d: start %q is not before limit %q", i, p.Start, p.Limit)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := partitions[i-1]
+		if prev.Limit == nil || p.Start == nil || b.compare(prev.Limit, p.Start) > 0 {
+			return fmt.Errorf("bulk load partition %d overlaps partition %d", i, i-1)
+		}
+	}
+	return nil
+}
+
+// writePartition writes the partition to sst files and returns their paths,
+// including the paths of the files written before an error.
+func (b *BulkLoader) writePartition(index int, p BulkLoadPartition, aborted func() bool) ([]string, error) {
+	envOpts := NewDefaultEnvOptions()
+	defer envOpts.Destroy()
+
+	var (
+		paths   []string
+		w       *SSTFileWriter
+		prevKey []byte
+		hasPrev bool
+		addErr  error
+	)
+	finish := func() error {
+		if w == nil {
+			return nil
+		}
+		err := w.Finish()
+		w.Destroy()
+		w = nil
+		return err
+	}
+	addEntry := func(key, value []byte) error {
+		if aborted() {
+			return errBulkLoadAborted
+		}
+		if hasPrev && b.compare(prevKey, key) >= 0 {
+			return fmt.Errorf("bulk load partition %d: key %q is not after key %q", index, key, prevKey)
+		}
+		if (p.Start != nil && b.compare(key, p.Start) < 0) || (p.Limit != nil && b.compare(key, p.Limit) >= 0) {
+			return fmt.Errorf("bulk load partition %d: key %q is out of range", index, key)
+		}
+		if w == nil {
+			path := filepath.Join(b.dir, fmt.Sprintf("bulk-%04d-%06d.sst", index, len(paths)))
+			w = NewSSTFileWriter(envOpts, b.opts)
+			paths = append(paths, path)
+			if err := w.Open(path); err != nil {
+				return err
+			}
+		}
+		if err := w.Add(key, value); err != nil {
+			return err
+		}
+		prevKey = append(prevKey[:0], key...)
+		hasPrev = true
+		if w.FileSize() >= b.targetFileSize {
+			return finish()
+		}
+		return nil
+	}
+
+	// remember the first error, in case Write does not return it
+	add := func(key, value []byte) error {
+		if addErr == nil {
+			addErr = addEntry(key, value)
+		}
+		return addErr
+	}
+
+	err := p.Write(add)
+	if err == nil {
+		err = addErr
+	}
+	if finishErr := finish(); err == nil {
+		err = finishErr
+	}
+	return paths, err
+}
+
+func (b *BulkLoader) compare(x, y []byte) int {
+	var cf *C.rocksdb_column_family_handle_t
+	if b.cf != nil {
+		cf = b.cf.c
+	}
+	return int(C.gorocksdb_column_family_compare(
+		b.db.c, cf, byteToChar(x), C.size_t(len(x)), byteToChar(y), C.size_t(len(y))))
+}
@@ -0,0 +1,126 @@
+package gorocksdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func testBulkLoadPartition(start, limit int) BulkLoadPartition {
+	return BulkLoadPartition{
+		Start: []byte(fmt.Sprintf("key%06d", start)),
+		Limit: []byte(fmt.Sprintf("key%06d", limit)),
+		Write: func(add func(key, value []byte) error) error {
+			for i := start; i < limit; i++ {
+				if err := add([]byte(fmt.Sprintf("key%06d", i)), []byte(fmt.Sprintf("value%d", i))); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestBulkLoader(t *testing.T) {
+	db := newTestDB(t, "TestBulkLoader", nil)
+	defer db.Close()
+
+	dir, err := ioutil.TempDir("", "gorocksdb-TestBulkLoader")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ingestOpts := NewDefaultIngestExternalFileOptions()
+	defer ingestOpts.Destroy()
+
+	loader := NewBulkLoader(db, nil, nil, dir)
+	loader.SetTargetFileSize(4 << 10)
+	loader.SetParallelism(2)
+	ensure.Nil(t, loader.Load(ingestOpts,
+		testBulkLoadPartition(0, 1000),
+		testBulkLoadPartition(1000, 2000),
+		testBulkLoadPartition(2000, 3000),
+	))
+
+	// several files per partition were ingested and removed afterwards
+	ensure.True(t, len(db.GetLiveFilesMetaData()) > 3)
+	infos, err := ioutil.ReadDir(dir)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(infos), 0)
+
+	ro := NewDefaultReadOptions()
+	for _, i := range []int{0, 999, 1000, 2999} {
+		value, err := db.GetBytes(ro, []byte(fmt.Sprintf("key%06d", i)))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, string(value), fmt.Sprintf("value%d", i))
+	}
+}
+
+func TestBulkLoaderInvalidInput(t *testing.T) {
+	db := newTestDB(t, "TestBulkLoaderInvalidInput", nil)
+	defer db.Close()
+
+	dir, err := ioutil.TempDir("", "gorocksdb-TestBulkLoaderInvalidInput")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ingestOpts := NewDefaultIngestExternalFileOptions()
+	defer ingestOpts.Destroy()
+	loader := NewBulkLoader(db, nil, NewDefaultOptions(), dir)
+
+	// overlapping partitions
+	err = loader.Load(ingestOpts, testBulkLoadPartition(0, 100), testBulkLoadPartition(50, 150))
+	ensure.NotNil(t, err)
+
+	// unsorted keys
+	err = loader.Load(ingestOpts, BulkLoadPartition{
+		Write: func(add func(key, value []byte) error) error {
+			if err := add([]byte("b"), []byte("1")); err != nil {
+				return err
+			}
+			return add([]byte("a"), []byte("2"))
+		},
+	})
+	ensure.NotNil(t, err)
+
+	// key out of the partition range
+	partition := testBulkLoadPartition(0, 100)
+	partition.Limit = []byte("key000050")
+	err = loader.Load(ingestOpts, partition)
+	ensure.NotNil(t, err)
+
+	// nothing was ingested
+	ensure.DeepEqual(t, len(db.GetLiveFilesMetaData()), 0)
+	infos, err := ioutil.ReadDir(dir)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(infos), 0)
+}
+
+func TestBulkLoaderColumnFamily(t *testing.T) {
+	db, cfh, cleanup := newTestDBCF(t, "TestBulkLoaderColumnFamily")
+	defer cleanup()
+
+	dir, err := ioutil.TempDir("", "gorocksdb-TestBulkLoaderColumnFamily")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ingestOpts := NewDefaultIngestExternalFileOptions()
+	defer ingestOpts.Destroy()
+
+	// the options of a column family are required
+	ensure.NotNil(t, NewBulkLoader(db, cfh[1], nil, dir).Load(ingestOpts, testBulkLoadPartition(0, 100)))
+
+	loader := NewBulkLoader(db, cfh[1], NewDefaultOptions(), dir)
+	ensure.Nil(t, loader.Load(ingestOpts, testBulkLoadPartition(0, 100)))
+
+	ro := NewDefaultReadOptions()
+	value, err := db.GetCF(ro, cfh[1], []byte("key000050"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, string(value.Data()), "value50")
+	value.Free()
+	defaultValue, err := db.GetBytes(ro, []byte("key000050"))
+	ensure.Nil(t, err)
+	ensure.True(t, defaultValue == nil)
+}
@@ -357,3 +357,81 @@ func TestColumnFamilyDeleteRange(t *testing.T) {
 	ensure.Nil(t, err)
 	ensure.True(t, val3.Data() == nil)
 }
+
+func TestColumnFamilySingleDelete(t *testing.T) {
+	db, cfh, cleanup := newTestDBCF(t, "TestColumnFamilySingleDelete")
+	defer cleanup()
+
+	var (
+		givenKey = []byte("hello")
+		givenVal = []byte("world")
+		wo       = NewDefaultWriteOptions()
+		ro       = NewDefaultReadOptions()
+	)
+	ensure.Nil(t, db.Put(wo, givenKey, givenVal))
+	ensure.Nil(t, db.PutCF(wo, cfh[1], givenKey, givenVal))
+
+	ensure.Nil(t, db.SingleDeleteCF(wo, cfh[1], givenKey))
+	val, err := db.GetCF(ro, cfh[1], givenKey)
+	defer val.Free()
+	ensure.Nil(t, err)
+	ensure.True(t, val.Data() == nil)
+
+	// the key of the default column family is kept
+	val0, err := db.Get(ro, givenKey)
+	defer val0.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, val0.Data(), givenVal)
+}
+
+func TestColumnFamilyGetProperty(t *testing.T) {
+	db, cfh, cleanup := newTestDBCF(t, "TestColumnFamilyGetProperty")
+	defer cleanup()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.PutCF(wo, cfh[1], []byte("hello1"), []byte("world1")))
+	ensure.Nil(t, db.PutCF(wo, cfh[1], []byte("hello2"), []byte("world2")))
+
+	numKeys, ok := db.GetIntPropertyCF(PropertyEstimateNumKeys, cfh[0])
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, numKeys, uint64(0))
+	numKeys, ok = db.GetIntPropertyCF(PropertyEstimateNumKeys, cfh[1])
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, numKeys, uint64(2))
+
+	stats := db.GetMapPropertyCF(PropertyCFStats, cfh[1])
+	ensure.NotNil(t, stats)
+	ensure.True(t, db.GetMapPropertyCF("rocksdb.unknown", cfh[1]) == nil)
+}
+
+func TestColumnFamilyGetMetaData(t *testing.T) {
+	db, cfh, cleanup := newTestDBCF(t, "TestColumnFamilyGetMetaData")
+	defer cleanup()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.PutCF(wo, cfh[1], []byte("key1"), []byte("value")))
+	ensure.Nil(t, db.PutCF(wo, cfh[1], []byte("key2"), []byte("value")))
+	db.CompactRangeCF(cfh[1], Range{})
+
+	meta := db.GetColumnFamilyMetaDataCF(cfh[1])
+	ensure.DeepEqual(t, meta.Name, "guide")
+	ensure.DeepEqual(t, meta.FileCount, 1)
+	ensure.True(t, meta.Size > 0)
+	ensure.True(t, len(meta.Levels) > 1)
+
+	var files []SstFileMetadata
+	var size int64
+	for _, level := range meta.Levels {
+		files = append(files, level.Files...)
+		size += level.Size
+	}
+	ensure.DeepEqual(t, size, meta.Size)
+	ensure.DeepEqual(t, len(files), 1)
+	ensure.DeepEqual(t, files[0].SmallestKey, []byte("key1"))
+	ensure.DeepEqual(t, files[0].LargestKey, []byte("key2"))
+	ensure.DeepEqual(t, files[0].Size, meta.Size)
+
+	// the default column family is empty
+	ensure.DeepEqual(t, db.GetColumnFamilyMetaData().FileCount, 0)
+}
@@ -2,12 +2,10 @@ package gorocksdb
 
 // #include <stdlib.h>
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 
-import (
-	"errors"
-	"unsafe"
-)
+import "unsafe"
 
 // Checkpoint provides Checkpoint functionality.
 // Checkpoints provide persistent snapshots of RocksDB databases.
@@ -44,13 +42,110 @@ func (checkpoint *Checkpoint) CreateCheckpoint(checkpointDir string, logSizeForF
 	C.rocksdb_checkpoint_create(checkpoint.c, cDir, C.uint64_t(logSizeForFlush), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
 
+// ExportColumnFamily flushes the memtable of the column family cf, exports
+// all its live table files into exportDir and returns their metadata, which
+// can be passed to DB.CreateColumnFamilyWithImport to import the column
+// family into another database. The table files are hard-linked if
+// possible, copied otherwise. The directory should not already exist and
+// will be created by this API.
+func (checkpoint *Checkpoint) ExportColumnFamily(cf *ColumnFamilyHandle, exportDir string) (*ExportImportFilesMetadata, error) {
+	var (
+		cErr *C.char
+	)
+
+	cDir := C.CString(exportDir)
+	defer C.free(unsafe.Pointer(cDir))
+
+	cMetadata := C.gorocksdb_checkpoint_export_column_family(checkpoint.c, cf.c, cDir, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	defer C.gorocksdb_export_import_files_metadata_destroy(cMetadata)
+
+	metadata := &ExportImportFilesMetadata{
+		ComparatorName: C.GoString(C.gorocksdb_export_import_files_metadata_db_comparator_name(cMetadata)),
+		Files:          make([]ExportedFileMetadata, int(C.gorocksdb_export_import_files_metadata_count(cMetadata))),
+	}
+	for i := range metadata.Files {
+		cIndex := C.int(i)
+		file := &metadata.Files[i]
+		file.Name = C.GoString(C.gorocksdb_export_import_files_metadata_file_name(cMetadata, cIndex))
+		file.Dir = C.GoString(C.gorocksdb_export_import_files_metadata_file_db_path(cMetadata, cIndex))
+		file.ColumnFamilyName = C.GoString(C.gorocksdb_export_import_files_metadata_file_column_family_name(cMetadata, cIndex))
+		file.Level = int(C.gorocksdb_export_import_files_metadata_file_level(cMetadata, cIndex))
+		file.Size = int64(C.gorocksdb_export_import_files_metadata_file_size(cMetadata, cIndex))
+		file.SmallestSeqno = uint64(C.gorocksdb_export_import_files_metadata_file_smallest_seqno(cMetadata, cIndex))
+		file.LargestSeqno = uint64(C.gorocksdb_export_import_files_metadata_file_largest_seqno(cMetadata, cIndex))
+		file.Entries = uint64(C.gorocksdb_export_import_files_metadata_file_num_entries(cMetadata, cIndex))
+		file.Deletions = uint64(C.gorocksdb_export_import_files_metadata_file_num_deletions(cMetadata, cIndex))
+
+		var cSize C.size_t
+		key := C.gorocksdb_export_import_files_metadata_file_smallest_key(cMetadata, cIndex, &cSize)
+		file.SmallestKey = C.GoBytes(unsafe.Pointer(key), C.int(cSize))
+
+		key = C.gorocksdb_export_import_files_metadata_file_largest_key(cMetadata, cIndex, &cSize)
+		file.LargestKey = C.GoBytes(unsafe.Pointer(key), C.int(cSize))
+	}
+	return metadata, nil
+}
+
 // Destroy deallocates the Checkpoint object.
 func (checkpoint *Checkpoint) Destroy() {
 	C.rocksdb_checkpoint_object_destroy(checkpoint.c)
 	checkpoint.c = nil
 }
+
+// ExportImportFilesMetadata describes the table files of a column family
+// exported with Checkpoint.ExportColumnFamily. It only holds Go values, so it
+// can be serialized to import the column family in another process.
+type ExportImportFilesMetadata struct {
+	// ComparatorName is the name of the comparator of the column family.
+	// The imported column family must use the same comparator.
+	ComparatorName string
+	Files          []ExportedFileMetadata
+}
+
+// ExportedFileMetadata is the metadata of an exported table file.
+type ExportedFileMetadata struct {
+	// Name is the name of the file, relative to Dir.
+	Name string
+	// Dir is the directory holding the file, usually the export directory.
+	Dir              string
+	ColumnFamilyName string
+	Level            int
+	Size             int64
+	SmallestKey      []byte
+	LargestKey       []byte
+	SmallestSeqno    uint64
+	LargestSeqno     uint64
+	Entries          uint64
+	Deletions        uint64
+}
+
+func (metadata *ExportImportFilesMetadata) toC() *C.gorocksdb_export_import_files_metadata_t {
+	cComparatorName := C.CString(metadata.ComparatorName)
+	defer C.free(unsafe.Pointer(cComparatorName))
+	cMetadata := C.gorocksdb_export_import_files_metadata_create(cComparatorName)
+	for _, file := range metadata.Files {
+		cName := C.CString(file.Name)
+		cDir := C.CString(file.Dir)
+		cCFName := C.CString(file.ColumnFamilyName)
+		cSmallestKey := byteToChar(file.SmallestKey)
+		cLargestKey := byteToChar(file.LargestKey)
+		C.gorocksdb_export_import_files_metadata_add_file(
+			cMetadata, cName, cDir, cCFName, C.int(file.Level), C.uint64_t(file.Size),
+			cSmallestKey, C.size_t(len(file.SmallestKey)), cLargestKey, C.size_t(len(file.LargestKey)),
+			C.uint64_t(file.SmallestSeqno), C.uint64_t(file.LargestSeqno),
+			C.uint64_t(file.Entries), C.uint64_t(file.Deletions))
+		C.free(unsafe.Pointer(cName))
+		C.free(unsafe.Pointer(cDir))
+		C.free(unsafe.Pointer(cCFName))
+	}
+	return cMetadata
+}
@@ -54,3 +54,44 @@ func TestCheckpoint(t *testing.T) {
 	}
 
 }
+
+func TestCheckpointExportImportColumnFamily(t *testing.T) {
+	db, cfh, cleanup := newTestDBCF(t, "TestCheckpointExportImportColumnFamily")
+	defer cleanup()
+
+	wo := NewDefaultWriteOptions()
+	givenKeys := [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}
+	for _, k := range givenKeys {
+		ensure.Nil(t, db.PutCF(wo, cfh[1], k, []byte("val")))
+	}
+
+	exportDir, err := ioutil.TempDir("", "gorocksdb-export")
+	ensure.Nil(t, err)
+	ensure.Nil(t, os.RemoveAll(exportDir))
+	defer os.RemoveAll(exportDir)
+
+	checkpoint, err := db.NewCheckpoint()
+	ensure.Nil(t, err)
+	defer checkpoint.Destroy()
+	metadata, err := checkpoint.ExportColumnFamily(cfh[1], exportDir)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, metadata.ComparatorName, "leveldb.BytewiseComparator")
+	ensure.DeepEqual(t, len(metadata.Files), 1)
+	ensure.DeepEqual(t, metadata.Files[0].SmallestKey, []byte("key1"))
+	ensure.DeepEqual(t, metadata.Files[0].LargestKey, []byte("key3"))
+	ensure.DeepEqual(t, metadata.Files[0].Entries, uint64(3))
+
+	target := newTestDB(t, "TestCheckpointExportImportColumnFamily-target", nil)
+	defer target.Close()
+	cf, err := target.CreateColumnFamilyWithImport(NewDefaultOptions(), "imported", metadata)
+	ensure.Nil(t, err)
+	defer cf.Destroy()
+
+	ro := NewDefaultReadOptions()
+	for _, k := range givenKeys {
+		value, err := target.GetCF(ro, cf, k)
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, value.Data(), []byte("val"))
+		value.Free()
+	}
+}
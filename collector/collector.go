@@ -0,0 +1,360 @@
+// Package collector samples metrics of RocksDB databases and caches and
+// exposes them in the Prometheus text exposition format.
+//
+//	c := collector.New()
+//	c.AddDB(db, cfHandles...)
+//	c.AddCache("block", cache)
+//	c.Start(15 * time.Second)
+//	defer c.Stop()
+//	http.Handle("/metrics", c)
+//
+// The databases and caches must not be closed or destroyed before the
+// collector is stopped.
+package collector
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tecbot/gorocksdb"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// maxLevels bounds the levels sampled with the num-files-at-level property.
+const maxLevels = 64
+
+// property is an integer property sampled for every column family.
+type property struct {
+	name   gorocksdb.Property
+	metric string
+	help   string
+}
+
+var properties = []property{
+	{gorocksdb.PropertyEstimateNumKeys, "rocksdb_estimate_num_keys", "Estimated number of keys."},
+	{gorocksdb.PropertyNumRunningCompactions, "rocksdb_num_running_compactions", "Number of currently running compactions."},
+	{gorocksdb.PropertyNumRunningFlushes, "rocksdb_num_running_flushes", "Number of currently running flushes."},
+	{gorocksdb.PropertyCompactionPending, "rocksdb_compaction_pending", "1 if at least one compaction is pending, otherwise 0."},
+	{gorocksdb.PropertyMemTableFlushPending, "rocksdb_mem_table_flush_pending", "1 if a memtable flush is pending, otherwise 0."},
+	{gorocksdb.PropertyNumImmutableMemTable, "rocksdb_num_immutable_mem_table", "Number of immutable memtables that have not yet been flushed."},
+	{gorocksdb.PropertyCurSizeAllMemTables, "rocksdb_cur_size_all_mem_tables_bytes", "Approximate size of active and unflushed immutable memtables."},
+	{gorocksdb.PropertySizeAllMemTables, "rocksdb_size_all_mem_tables_bytes", "Approximate size of active, unflushed immutable and pinned immutable memtables."},
+	{gorocksdb.PropertyEstimatePendingCompactionBytes, "rocksdb_estimate_pending_compaction_bytes", "Estimated total number of bytes compaction needs to rewrite."},
+	{gorocksdb.PropertyEstimateLiveDataSize, "rocksdb_estimate_live_data_size_bytes", "Estimated size of the live data."},
+	{gorocksdb.PropertyLiveSstFilesSize, "rocksdb_live_sst_files_size_bytes", "Total size of all SST files belonging to the latest version."},
+	{gorocksdb.PropertyTotalSstFilesSize, "rocksdb_total_sst_files_size_bytes", "Total size of all SST files."},
+	{gorocksdb.PropertyEstimateTableReadersMem, "rocksdb_estimate_table_readers_mem_bytes", "Estimated memory used by table readers, excluding the block cache."},
+	{gorocksdb.PropertyNumSnapshots, "rocksdb_num_snapshots", "Number of unreleased snapshots."},
+	{gorocksdb.PropertyNumLiveVersions, "rocksdb_num_live_versions", "Number of live versions."},
+	{gorocksdb.PropertyActualDelayedWriteRate, "rocksdb_actual_delayed_write_rate", "Current actual delayed write rate, 0 means no delay."},
+	{gorocksdb.PropertyIsWriteStopped, "rocksdb_is_write_stopped", "1 if writes have been stopped, otherwise 0."},
+}
+
+const (
+	metricNumFilesAtLevel = "rocksdb_num_files_at_level"
+	helpNumFilesAtLevel   = "Number of files at level."
+)
+
+// Metric families which are not sampled from column family properties.
+var (
+	cacheMetrics = []struct {
+		metric string
+		help   string
+		get    func(*gorocksdb.Cache) int
+	}{
+		{"rocksdb_cache_usage_bytes", "Memory size of the entries residing in the cache.", (*gorocksdb.Cache).GetUsage},
+		{"rocksdb_cache_pinned_usage_bytes", "Memory size of the entries in use by the system.", (*gorocksdb.Cache).GetPinnedUsage},
+	}
+	memoryUsageMetrics = []struct {
+		metric string
+		help   string
+		get    func(*gorocksdb.MemoryUsage) uint64
+	}{
+		{"rocksdb_memory_mem_table_total_bytes", "Approximate memory usage of all memtables.", func(m *gorocksdb.MemoryUsage) uint64 { return m.MemTableTotal }},
+		{"rocksdb_memory_mem_table_unflushed_bytes", "Approximate memory usage of unflushed memtables.", func(m *gorocksdb.MemoryUsage) uint64 { return m.MemTableUnflushed }},
+		{"rocksdb_memory_mem_table_readers_total_bytes", "Approximate memory usage of table readers.", func(m *gorocksdb.MemoryUsage) uint64 { return m.MemTableReadersTotal }},
+		{"rocksdb_memory_cache_total_bytes", "Approximate memory usage of the caches.", func(m *gorocksdb.MemoryUsage) uint64 { return m.CacheTotal }},
+	}
+)
+
+type dbSource struct {
+	db  *gorocksdb.DB
+	cfs []*gorocksdb.ColumnFamilyHandle
+}
+
+type cacheSource struct {
+	name  string
+	cache *gorocksdb.Cache
+}
+
+// label is a single name="value" pair of a sample.
+type label struct {
+	name  string
+	value string
+}
+
+// sample is a single value of a metric family.
+type sample struct {
+	labels []label
+	value  float64
+}
+
+// family is a metric family with its samples.
+type family struct {
+	name    string
+	help    string
+	samples []sample
+}
+
+// Collector samples the metrics of the registered databases and caches.
+// A Collector is an http.Handler serving the latest samples.
+type Collector struct {
+	mu       sync.Mutex
+	dbs      []dbSource
+	caches   []cacheSource
+	families []family
+	sampled  bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates a collector without databases or caches.
+func New() *Collector {
+	return &Collector{}
+}
+
+// AddDB registers a database and the column families to sample. If no
+// column family handles are given, only the default column family is sampled.
+// Samples are labeled with the name of the database and the name of the
+// column family.
+func (c *Collector) AddDB(db *gorocksdb.DB, cfs ...*gorocksdb.ColumnFamilyHandle) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dbs = append(c.dbs, dbSource{db: db, cfs: cfs})
+}
+
+// AddCache registers a cache. Samples are labeled with the given name.
+func (c *Collector) AddCache(name string, cache *gorocksdb.Cache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.caches = append(c.caches, cacheSource{name: name, cache: cache})
+}
+
+// Collect samples all registered databases and caches and replaces the
+// samples served by the collector. Properties which are not supported by a
+// database are skipped. If the memory usage can not be determined, the other
+// samples are still replaced and the error is returned.
+func (c *Collector) Collect() error {
+	c.mu.Lock()
+	dbs := append([]dbSource(nil), c.dbs...)
+	caches := append([]cacheSource(nil), c.caches...)
+	c.mu.Unlock()
+
+	families, err := collect(dbs, caches)
+
+	c.mu.Lock()
+	c.families = families
+	c.sampled = true
+	c.mu.Unlock()
+	return err
+}
+
+// Start samples the registered databases and caches every interval in a
+// background goroutine until Stop is called. Errors are ignored, the
+// affected samples are left out until the next interval.
+func (c *Collector) Start(interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		return
+	}
+	c.stop = make(chan struct{})
+	c.done = make(chan struct{})
+	go c.run(interval, c.stop, c.done)
+}
+
+func (c *Collector) run(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	c.Collect()
+	for {
+		select {
+		case <-ticker.C:
+			c.Collect()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Stop stops the background sampling started with Start and waits until it
+// has finished.
+func (c *Collector) Stop() {
+	c.mu.Lock()
+	stop, done := c.stop, c.done
+	c.stop, c.done = nil, nil
+	c.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// WriteTo writes the latest samples in the Prometheus text exposition
+// format. If nothing has been sampled yet, it calls Collect first.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	sampled := c.sampled
+	c.mu.Unlock()
+	if !sampled {
+		c.Collect()
+	}
+
+	c.mu.Lock()
+	families := c.families
+	c.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	writeFamilies(bw, families)
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// ServeHTTP implements http.Handler.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	c.WriteTo(w)
+}
+
+func collect(dbs []dbSource, caches []cacheSource) ([]family, error) {
+	byName := make(map[string]*family)
+	var families []*family
+	add := func(name, help string, value float64, labels ...label) {
+		f, ok := byName[name]
+		if !ok {
+			f = &family{name: name, help: help}
+			byName[name] = f
+			families = append(families, f)
+		}
+		f.samples = append(f.samples, sample{labels: labels, value: value})
+	}
+
+	for _, src := range dbs {
+		cfs := src.cfs
+		if len(cfs) == 0 {
+			cfs = []*gorocksdb.ColumnFamilyHandle{nil}
+		}
+		for _, cf := range cfs {
+			cfName := "default"
+			getProperty := src.db.GetIntProperty
+			if cf != nil {
+				cfName = cf.Name()
+				getProperty = func(name gorocksdb.Property) (uint64, bool) { return src.db.GetIntPropertyCF(name, cf) }
+			}
+			labels := []label{{"db", src.db.Name()}, {"cf", cfName}}
+			for _, p := range properties {
+				if v, ok := getProperty(p.name); ok {
+					add(p.metric, p.help, float64(v), labels...)
+				}
+			}
+			for level := 0; level < maxLevels; level++ {
+				v, ok := getProperty(gorocksdb.PropertyNumFilesAtLevel(level))
+				if !ok {
+					break
+				}
+				add(metricNumFilesAtLevel, helpNumFilesAtLevel, float64(v), append(labels, label{"level", strconv.Itoa(level)})...)
+			}
+		}
+	}
+
+	for _, src := range caches {
+		for _, m := range cacheMetrics {
+			add(m.metric, m.help, float64(m.get(src.cache)), label{"cache", src.name})
+		}
+	}
+
+	var err error
+	if len(dbs) > 0 || len(caches) > 0 {
+		allDBs := make([]*gorocksdb.DB, len(dbs))
+		for i, src := range dbs {
+			allDBs[i] = src.db
+		}
+		allCaches := make([]*gorocksdb.Cache, len(caches))
+		for i, src := range caches {
+			allCaches[i] = src.cache
+		}
+		var usage *gorocksdb.MemoryUsage
+		if usage, err = gorocksdb.GetApproximateMemoryUsageByType(allDBs, allCaches); err == nil {
+			for _, m := range memoryUsageMetrics {
+				add(m.metric, m.help, float64(m.get(usage)))
+			}
+		}
+	}
+
+	result := make([]family, len(families))
+	for i, f := range families {
+		result[i] = *f
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+	return result, err
+}
+
+func writeFamilies(w *bufio.Writer, families []family) {
+	for _, f := range families {
+		fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		fmt.Fprintf(w, "# TYPE %s gauge\n", f.name)
+		for _, s := range f.samples {
+			w.WriteString(f.name)
+			if len(s.labels) > 0 {
+				w.WriteByte('{')
+				for i, l := range s.labels {
+					if i > 0 {
+						w.WriteByte(',')
+					}
+					fmt.Fprintf(w, "%s=\"%s\"", l.name, escapeLabelValue(l.value))
+				}
+				w.WriteByte('}')
+			}
+			w.WriteByte(' ')
+			w.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+			w.WriteByte('\n')
+		}
+	}
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
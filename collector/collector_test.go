@@ -0,0 +1,130 @@
+package collector
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+	"github.com/tecbot/gorocksdb"
+)
+
+func TestWriteFamilies(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeFamilies(w, []family{
+		{
+			name: "rocksdb_estimate_num_keys",
+			help: "Estimated number of keys.",
+			samples: []sample{
+				{labels: []label{{"db", `/tmp/a"b\c`}, {"cf", "default"}}, value: 42},
+			},
+		},
+		{
+			name:    "rocksdb_memory_cache_total_bytes",
+			help:    "Approximate memory usage\nof the caches.",
+			samples: []sample{{value: 1 << 20}},
+		},
+	})
+	ensure.Nil(t, w.Flush())
+	ensure.DeepEqual(t, buf.String(), `# HELP rocksdb_estimate_num_keys Estimated number of keys.
+# TYPE rocksdb_estimate_num_keys gauge
+rocksdb_estimate_num_keys{db="/tmp/a\"b\\c",cf="default"} 42
+# HELP rocksdb_memory_cache_total_bytes Approximate memory usage\nof the caches.
+# TYPE rocksdb_memory_cache_total_bytes gauge
+rocksdb_memory_cache_total_bytes 1.048576e+06
+`)
+}
+
+func TestCollectorServeHTTP(t *testing.T) {
+	cache := gorocksdb.NewLRUCache(8 * 1024 * 1024)
+	defer cache.Destroy()
+	db, cfs := newTestDB(t, "TestCollectorServeHTTP", cache)
+	defer db.Close()
+	defer func() {
+		for _, cf := range cfs {
+			cf.Destroy()
+		}
+	}()
+
+	wo := gorocksdb.NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.PutCF(wo, cfs[1], []byte("hello"), []byte("world")))
+	fo := gorocksdb.NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ensure.Nil(t, db.Flush(fo))
+
+	c := New()
+	c.AddDB(db, cfs...)
+	c.AddCache("block", cache)
+	ensure.Nil(t, c.Collect())
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	ensure.DeepEqual(t, rec.Code, http.StatusOK)
+	ensure.DeepEqual(t, rec.Header().Get("Content-Type"), ContentType)
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE rocksdb_estimate_num_keys gauge\n",
+		`rocksdb_estimate_num_keys{db="` + db.Name() + `",cf="default"} 0` + "\n",
+		`rocksdb_estimate_num_keys{db="` + db.Name() + `",cf="other"} 1` + "\n",
+		`rocksdb_num_running_compactions{db="` + db.Name() + `",cf="default"} `,
+		`rocksdb_cur_size_all_mem_tables_bytes{db="` + db.Name() + `",cf="other"} `,
+		`rocksdb_estimate_pending_compaction_bytes{db="` + db.Name() + `",cf="other"} `,
+		`rocksdb_num_files_at_level{db="` + db.Name() + `",cf="default",level="0"} `,
+		`rocksdb_cache_usage_bytes{cache="block"} `,
+		`rocksdb_cache_pinned_usage_bytes{cache="block"} `,
+		"rocksdb_memory_mem_table_total_bytes ",
+		"rocksdb_memory_cache_total_bytes ",
+	} {
+		ensure.True(t, strings.Contains(body, want), want, body)
+	}
+}
+
+func TestCollectorStartStop(t *testing.T) {
+	db, cfs := newTestDB(t, "TestCollectorStartStop", nil)
+	defer db.Close()
+	defer func() {
+		for _, cf := range cfs {
+			cf.Destroy()
+		}
+	}()
+
+	c := New()
+	c.AddDB(db, cfs...)
+	c.Start(time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	c.Stop()
+	c.Stop()
+
+	var buf bytes.Buffer
+	_, err := c.WriteTo(&buf)
+	ensure.Nil(t, err)
+	ensure.True(t, strings.Contains(buf.String(), "rocksdb_estimate_num_keys{"))
+}
+
+func newTestDB(t *testing.T, name string, cache *gorocksdb.Cache) (*gorocksdb.DB, []*gorocksdb.ColumnFamilyHandle) {
+	dir, err := ioutil.TempDir("", "gorocksdb-"+name)
+	ensure.Nil(t, err)
+
+	opts := gorocksdb.NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+	opts.SetCreateIfMissingColumnFamilies(true)
+	if cache != nil {
+		bbto := gorocksdb.NewDefaultBlockBasedTableOptions()
+		bbto.SetBlockCache(cache)
+		opts.SetBlockBasedTableFactory(bbto)
+	}
+	db, cfs, err := gorocksdb.OpenDbColumnFamilies(opts, dir, []*gorocksdb.ColumnFamilyDescriptor{
+		{Name: "default", Options: opts},
+		{Name: "other", Options: opts},
+	})
+	ensure.Nil(t, err)
+	return db, cfs
+}
@@ -0,0 +1,268 @@
+package gorocksdb
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Context-aware variants of the read and write methods. RocksDB calls can not
+// be interrupted, so the context is checked before a call and, for calls made
+// of several steps, between the steps. If the context is done, the methods
+// return context.Canceled or context.DeadlineExceeded.
+
+// multiGetCtxBatchSize is the number of keys read by MultiGetCtx before the
+// context is checked again.
+const multiGetCtxBatchSize = 128
+
+// contextError returns the error of the context instead of err if err is a
+// lock timeout or an expiration and the context is done.
+func contextError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrTimedOut) && !errors.Is(err, ErrExpired) {
+		return err
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	// the timer of the context may not have fired yet
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// GetCtx is like Get but returns the error of the context if it is done.
+func (db *DB) GetCtx(ctx context.Context, opts *ReadOptions, key []byte) (*Slice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return db.Get(opts, key)
+}
+
+// GetCFCtx is like GetCF but returns the error of the context if it is done.
+func (db *DB) GetCFCtx(ctx context.Context, opts *ReadOptions, cf *ColumnFamilyHandle, key []byte) (*Slice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return db.GetCF(opts, cf, key)
+}
+
+// MultiGetCtx is like MultiGet but reads the keys in batches and stops
+// with the error of the context once it is done.
+func (db *DB) MultiGetCtx(ctx context.Context, opts *ReadOptions, keys ...[]byte) (Slices, error) {
+	return multiGetCtx(ctx, keys, func(keys [][]byte) (Slices, error) {
+		return db.MultiGet(opts, keys...)
+	})
+}
+
+// MultiGetCFCtx is like MultiGetCF but reads the keys in batches and stops
+// with the error of the context once it is done.
+func (db *DB) MultiGetCFCtx(ctx context.Context, opts *ReadOptions, cf *ColumnFamilyHandle, keys ...[]byte) (Slices, error) {
+	return multiGetCtx(ctx, keys, func(keys [][]byte) (Slices, error) {
+		return db.MultiGetCF(opts, cf, keys...)
+	})
+}
+
+func multiGetCtx(ctx context.Context, keys [][]byte, multiGet func([][]byte) (Slices, error)) (Slices, error) {
+	slices := make(Slices, 0, len(keys))
+	for start := 0; start < len(keys); start += multiGetCtxBatchSize {
+		if err := ctx.Err(); err != nil {
+			slices.Destroy()
+			return nil, err
+		}
+		end := start + multiGetCtxBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batch, err := multiGet(keys[start:end])
+		if err != nil {
+			slices.Destroy()
+			return nil, err
+		}
+		slices = append(slices, batch...)
+	}
+	return slices, nil
+}
+
+// PutCtx is like Put but returns the error of the context if it is done.
+func (db *DB) PutCtx(ctx context.Context, opts *WriteOptions, key, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.Put(opts, key, value)
+}
+
+// PutCFCtx is like PutCF but returns the error of the context if it is done.
+func (db *DB) PutCFCtx(ctx context.Context, opts *WriteOptions, cf *ColumnFamilyHandle, key, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.PutCF(opts, cf, key, value)
+}
+
+// DeleteCtx is like Delete but returns the error of the context if it is done.
+func (db *DB) DeleteCtx(ctx context.Context, opts *WriteOptions, key []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.Delete(opts, key)
+}
+
+// DeleteCFCtx is like DeleteCF but returns the error of the context if it is
+// done.
+func (db *DB) DeleteCFCtx(ctx context.Context, opts *WriteOptions, cf *ColumnFamilyHandle, key []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.DeleteCF(opts, cf, key)
+}
+
+// WriteCtx is like Write but returns the error of the context if it is done.
+func (db *DB) WriteCtx(ctx context.Context, opts *WriteOptions, batch *WriteBatch) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.Write(opts, batch)
+}
+
+// NewIteratorCtx is like NewIterator but the returned iterator becomes
+// invalid once the context is done. Its Err method returns the error of the
+// context then.
+func (db *DB) NewIteratorCtx(ctx context.Context, opts *ReadOptions) *Iterator {
+	iter := db.NewIterator(opts)
+	iter.ctx = ctx
+	return iter
+}
+
+// NewIteratorCFCtx is like NewIteratorCF but the returned iterator becomes
+// invalid once the context is done. Its Err method returns the error of the
+// context then.
+func (db *DB) NewIteratorCFCtx(ctx context.Context, opts *ReadOptions, cf *ColumnFamilyHandle) *Iterator {
+	iter := db.NewIteratorCF(opts, cf)
+	iter.ctx = ctx
+	return iter
+}
+
+// GetCtx is like Get but returns the error of the context if it is done.
+func (db *TransactionDB) GetCtx(ctx context.Context, opts *ReadOptions, key []byte) (*Slice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return db.Get(opts, key)
+}
+
+// PutCtx is like Put but returns the error of the context if it is done.
+// The lock wait of the write is not bound to the context.
+func (db *TransactionDB) PutCtx(ctx context.Context, opts *WriteOptions, key, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.Put(opts, key, value)
+}
+
+// DeleteCtx is like Delete but returns the error of the context if it is
+// done. The lock wait of the write is not bound to the context.
+func (db *TransactionDB) DeleteCtx(ctx context.Context, opts *WriteOptions, key []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.Delete(opts, key)
+}
+
+// TransactionBeginCtx is like TransactionBegin but bounds the transaction
+// by the deadline of the context: if the context has a deadline, the
+// transaction is begun with a copy of transactionOpts whose lock timeout and
+// expiration are set to the time left until the deadline. transactionOpts
+// is not changed. Use the context-aware methods of the Transaction, like
+// GetForUpdateCtx, to get the error of the context when a lock wait or the
+// transaction runs into the deadline.
+//
+// If the context is already done, its error is returned.
+func (db *TransactionDB) TransactionBeginCtx(
+	ctx context.Context,
+	opts *WriteOptions,
+	transactionOpts *TransactionOptions,
+	oldTransaction *Transaction,
+) (*Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		// round up, so that timeouts happen after the deadline
+		timeout := int64((time.Until(deadline) + time.Millisecond - 1) / time.Millisecond)
+		if timeout < 1 {
+			timeout = 1
+		}
+		deadlineOpts := transactionOpts.clone()
+		defer deadlineOpts.Destroy()
+		deadlineOpts.SetLockTimeout(timeout)
+		deadlineOpts.SetExpiration(timeout)
+		transactionOpts = deadlineOpts
+	}
+	return db.TransactionBegin(opts, transactionOpts, oldTransaction), nil
+}
+
+// GetCtx is like Get but returns the error of the context if it is done.
+func (transaction *Transaction) GetCtx(ctx context.Context, opts *ReadOptions, key []byte) (*Slice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	v, err := transaction.Get(opts, key)
+	return v, contextError(ctx, err)
+}
+
+// GetForUpdateCtx is like GetForUpdate but returns the error of the context
+// if it is done, including when waiting for the lock timed out because of
+// the deadline of the context.
+func (transaction *Transaction) GetForUpdateCtx(ctx context.Context, opts *ReadOptions, key []byte) (*Slice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	v, err := transaction.GetForUpdate(opts, key)
+	return v, contextError(ctx, err)
+}
+
+// GetForUpdateCFCtx is like GetForUpdateCF but returns the error of the
+// context if it is done, including when waiting for the lock timed out
+// because of the deadline of the context.
+func (transaction *Transaction) GetForUpdateCFCtx(ctx context.Context, opts *ReadOptions, cf *ColumnFamilyHandle, key []byte) (*Slice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	v, err := transaction.GetForUpdateCF(opts, cf, key)
+	return v, contextError(ctx, err)
+}
+
+// PutCtx is like Put but returns the error of the context if it is done,
+// including when waiting for the lock timed out because of the deadline of
+// the context.
+func (transaction *Transaction) PutCtx(ctx context.Context, key, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return contextError(ctx, transaction.Put(key, value))
+}
+
+// DeleteCtx is like Delete but returns the error of the context if it is
+// done, including when waiting for the lock timed out because of the
+// deadline of the context.
+func (transaction *Transaction) DeleteCtx(ctx context.Context, key []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return contextError(ctx, transaction.Delete(key))
+}
+
+// CommitCtx is like Commit but returns the error of the context if it is
+// done, including when the transaction expired because of the deadline of
+// the context. The transaction is not committed then and should be rolled
+// back.
+func (transaction *Transaction) CommitCtx(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return contextError(ctx, transaction.Commit())
+}
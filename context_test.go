@@ -0,0 +1,137 @@
+package gorocksdb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestContextError(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	other := errors.New("other")
+
+	ensure.Nil(t, contextError(canceled, nil))
+	ensure.DeepEqual(t, contextError(context.Background(), ErrTimedOut), error(ErrTimedOut))
+	ensure.DeepEqual(t, contextError(canceled, other), other)
+	ensure.DeepEqual(t, contextError(canceled, ParseError("Operation timed out: Timeout waiting to lock key")), context.Canceled)
+	ensure.DeepEqual(t, contextError(expired, ParseError("Operation expired: ")), context.DeadlineExceeded)
+}
+
+func TestDBContextCanceled(t *testing.T) {
+	db := newTestDB(t, "TestDBContextCanceled", nil)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+
+	ensure.DeepEqual(t, db.PutCtx(ctx, wo, []byte("hello"), []byte("world")), context.Canceled)
+	_, err := db.GetCtx(ctx, ro, []byte("hello"))
+	ensure.DeepEqual(t, err, context.Canceled)
+	_, err = db.MultiGetCtx(ctx, ro, []byte("hello"))
+	ensure.DeepEqual(t, err, context.Canceled)
+
+	v, err := db.GetBytes(ro, []byte("hello"))
+	ensure.Nil(t, err)
+	ensure.True(t, v == nil)
+}
+
+func TestDBMultiGetCtx(t *testing.T) {
+	db := newTestDB(t, "TestDBMultiGetCtx", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+
+	keys := make([][]byte, 3*multiGetCtxBatchSize+1)
+	for i := range keys {
+		keys[i] = []byte("key" + strconv.Itoa(i))
+		ensure.Nil(t, db.Put(wo, keys[i], []byte("value"+strconv.Itoa(i))))
+	}
+
+	values, err := db.MultiGetCtx(context.Background(), ro, keys...)
+	ensure.Nil(t, err)
+	defer values.Destroy()
+	ensure.DeepEqual(t, len(values), len(keys))
+	for i, v := range values {
+		ensure.DeepEqual(t, v.Data(), []byte("value"+strconv.Itoa(i)))
+	}
+}
+
+func TestIteratorCtx(t *testing.T) {
+	db := newTestDB(t, "TestIteratorCtx", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	for i := 0; i < 10; i++ {
+		ensure.Nil(t, db.Put(wo, []byte("key"+strconv.Itoa(i)), []byte("value")))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	iter := db.NewIteratorCtx(ctx, ro)
+	defer iter.Close()
+
+	n := 0
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		n++
+		if n == 3 {
+			cancel()
+		}
+	}
+	ensure.DeepEqual(t, n, 3)
+	ensure.DeepEqual(t, iter.Err(), context.Canceled)
+}
+
+func TestTransactionBeginCtx(t *testing.T) {
+	db := newTestTransactionDB(t, "TestTransactionBeginCtx", nil)
+	defer db.Close()
+
+	var (
+		givenKey = []byte("hello")
+		wo       = NewDefaultWriteOptions()
+		ro       = NewDefaultReadOptions()
+		to       = NewDefaultTransactionOptions()
+	)
+	defer wo.Destroy()
+	defer ro.Destroy()
+	defer to.Destroy()
+
+	txn1 := db.TransactionBegin(wo, to, nil)
+	defer txn1.Destroy()
+	v, err := txn1.GetForUpdate(ro, givenKey)
+	ensure.Nil(t, err)
+	v.Free()
+
+	// the second transaction waits for the lock until the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	txn2, err := db.TransactionBeginCtx(ctx, wo, to, nil)
+	ensure.Nil(t, err)
+	defer txn2.Destroy()
+	_, err = txn2.GetForUpdateCtx(ctx, ro, givenKey)
+	ensure.DeepEqual(t, err, context.DeadlineExceeded)
+
+	// the timeouts of the deadline are not applied to the options
+	ensure.DeepEqual(t, to.GetLockTimeout(), int64(-1))
+	ensure.DeepEqual(t, to.GetExpiration(), int64(-1))
+
+	_, err = db.TransactionBeginCtx(ctx, wo, to, nil)
+	ensure.DeepEqual(t, err, context.DeadlineExceeded)
+}
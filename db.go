@@ -2,6 +2,7 @@ package gorocksdb
 
 // #include <stdlib.h>
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 import (
 	"errors"
@@ -33,7 +34,7 @@ func OpenDb(opts *Options, name string) (*DB, error) {
 	db := C.rocksdb_open(opts.c, cName, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return &DB{
 		name: name,
@@ -52,7 +53,7 @@ func OpenDbWithTTL(opts *Options, name string, ttl int) (*DB, error) {
 	db := C.rocksdb_open_with_ttl(opts.c, cName, C.int(ttl), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return &DB{
 		name: name,
@@ -71,7 +72,7 @@ func OpenDbForReadOnly(opts *Options, name string, errorIfLogFileExist bool) (*D
 	db := C.rocksdb_open_for_read_only(opts.c, cName, boolToChar(errorIfLogFileExist), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return &DB{
 		name: name,
@@ -123,7 +124,7 @@ func OpenDbColumnFamilies(
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, nil, errors.New(C.GoString(cErr))
+		return nil, nil, newError(C.GoString(cErr))
 	}
 
 	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
@@ -184,7 +185,96 @@ func OpenDbForReadOnlyColumnFamilies(
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, nil, errors.New(C.GoString(cErr))
+		return nil, nil, newError(C.GoString(cErr))
+	}
+
+	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
+	for i, c := range cHandles {
+		cfHandles[i] = NewNativeColumnFamilyHandle(c, cfDescriptors[i].Name)
+	}
+
+	return &DB{
+		name: name,
+		c:    db,
+		opts: opts,
+	}, cfHandles, nil
+}
+
+// OpenDbAsSecondary opens a database as a secondary instance of the primary
+// database name. A secondary instance is read-only and follows the primary
+// when TryCatchUpWithPrimary is called. secondaryPath is a directory used by
+// the secondary for its info log and must be different for each instance.
+// opts must set SetMaxOpenFiles(-1).
+func OpenDbAsSecondary(opts *Options, name, secondaryPath string) (*DB, error) {
+	var (
+		cErr           *C.char
+		cName          = C.CString(name)
+		cSecondaryPath = C.CString(secondaryPath)
+	)
+	defer C.free(unsafe.Pointer(cName))
+	defer C.free(unsafe.Pointer(cSecondaryPath))
+	db := C.rocksdb_open_as_secondary(opts.c, cName, cSecondaryPath, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return &DB{
+		name: name,
+		c:    db,
+		opts: opts,
+	}, nil
+}
+
+// OpenDbAsSecondaryColumnFamilies opens a database with the specified column
+// families as a secondary instance, see OpenDbAsSecondary. The column
+// families can be a subset of the column families of the primary.
+func OpenDbAsSecondaryColumnFamilies(
+	opts *Options,
+	name string,
+	secondaryPath string,
+	cfDescriptors []*ColumnFamilyDescriptor,
+) (*DB, []*ColumnFamilyHandle, error) {
+	numColumnFamilies := len(cfDescriptors)
+	if numColumnFamilies == 0 {
+		return nil, nil, errors.New("must provide the column family names and options")
+	}
+
+	var (
+		cNames = make([]*C.char, numColumnFamilies)
+		cOpts  = make([]*C.rocksdb_options_t, numColumnFamilies)
+	)
+	for i, s := range cfDescriptors {
+		cNames[i] = C.CString(s.Name)
+		cOpts[i] = s.Options.c
+	}
+	defer func() {
+		for _, s := range cNames {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	var (
+		cErr           *C.char
+		cName          = C.CString(name)
+		cSecondaryPath = C.CString(secondaryPath)
+		cHandles       = make([]*C.rocksdb_column_family_handle_t, numColumnFamilies)
+	)
+	defer C.free(unsafe.Pointer(cName))
+	defer C.free(unsafe.Pointer(cSecondaryPath))
+
+	db := C.rocksdb_open_as_secondary_column_families(
+		opts.c,
+		cName,
+		cSecondaryPath,
+		C.int(numColumnFamilies),
+		&cNames[0],
+		&cOpts[0],
+		&cHandles[0],
+		&cErr,
+	)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, nil, newError(C.GoString(cErr))
 	}
 
 	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
@@ -210,7 +300,7 @@ func ListColumnFamilies(opts *Options, name string) ([]string, error) {
 	cNames := C.rocksdb_list_column_families(opts.c, cName, &cLen, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	namesLen := int(cLen)
 	names := make([]string, namesLen)
@@ -242,7 +332,7 @@ func (db *DB) Get(opts *ReadOptions, key []byte) (*Slice, error) {
 	cValue := C.rocksdb_get(db.c, opts.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return NewSlice(cValue, cValLen), nil
 }
@@ -257,7 +347,7 @@ func (db *DB) GetBytes(opts *ReadOptions, key []byte) ([]byte, error) {
 	cValue := C.rocksdb_get(db.c, opts.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	if cValue == nil {
 		return nil, nil
@@ -276,7 +366,7 @@ func (db *DB) GetCF(opts *ReadOptions, cf *ColumnFamilyHandle, key []byte) (*Sli
 	cValue := C.rocksdb_get_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return NewSlice(cValue, cValLen), nil
 }
@@ -305,13 +395,13 @@ func (db *DB) MultiGet(opts *ReadOptions, keys ...[]byte) (Slices, error) {
 	for i, rocksErr := range rocksErrs {
 		if rocksErr != nil {
 			defer C.free(unsafe.Pointer(rocksErr))
-			err := fmt.Errorf("getting %q failed: %v", string(keys[i]), C.GoString(rocksErr))
+			err := fmt.Errorf("getting %q failed: %w", string(keys[i]), newError(C.GoString(rocksErr)))
 			errs = append(errs, err)
 		}
 	}
 
 	if len(errs) > 0 {
-		return nil, fmt.Errorf("failed to get %d keys, first error: %v", len(errs), errs[0])
+		return nil, fmt.Errorf("failed to get %d keys, first error: %w", len(errs), errs[0])
 	}
 
 	slices := make(Slices, len(keys))
@@ -357,13 +447,13 @@ func (db *DB) MultiGetCFMultiCF(opts *ReadOptions, cfs ColumnFamilyHandles, keys
 	for i, rocksErr := range rocksErrs {
 		if rocksErr != nil {
 			defer C.free(unsafe.Pointer(rocksErr))
-			err := fmt.Errorf("getting %q failed: %v", string(keys[i]), C.GoString(rocksErr))
+			err := fmt.Errorf("getting %q failed: %w", string(keys[i]), newError(C.GoString(rocksErr)))
 			errs = append(errs, err)
 		}
 	}
 
 	if len(errs) > 0 {
-		return nil, fmt.Errorf("failed to get %d keys, first error: %v", len(errs), errs[0])
+		return nil, fmt.Errorf("failed to get %d keys, first error: %w", len(errs), errs[0])
 	}
 
 	slices := make(Slices, len(keys))
@@ -384,7 +474,7 @@ func (db *DB) Put(opts *WriteOptions, key, value []byte) error {
 	C.rocksdb_put(db.c, opts.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -399,7 +489,7 @@ func (db *DB) PutCF(opts *WriteOptions, cf *ColumnFamilyHandle, key, value []byt
 	C.rocksdb_put_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -420,11 +510,20 @@ func (db *DB) Delete(opts *WriteOptions, key []byte) error {
 	C.rocksdb_delete(db.c, opts.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
 
+// SingleDelete removes the data associated with the key from the database,
+// see WriteBatch.SingleDelete for the restrictions of single deletions.
+func (db *DB) SingleDelete(opts *WriteOptions, key []byte) error {
+	batch := NewWriteBatch()
+	defer batch.Destroy()
+	batch.SingleDelete(key)
+	return db.Write(opts, batch)
+}
+
 // DeleteRangeCF removes the database entries in the range ["begin_key", "end_key").
 func (db *DB) DeleteRangeCF(opts *WriteOptions, cf *ColumnFamilyHandle, begin, end []byte) error {
 	batch := NewWriteBatch()
@@ -432,6 +531,16 @@ func (db *DB) DeleteRangeCF(opts *WriteOptions, cf *ColumnFamilyHandle, begin, e
 	return db.Write(opts, batch)
 }
 
+// SingleDeleteCF removes the data associated with the key from the database
+// and column family, see WriteBatch.SingleDelete for the restrictions of
+// single deletions.
+func (db *DB) SingleDeleteCF(opts *WriteOptions, cf *ColumnFamilyHandle, key []byte) error {
+	batch := NewWriteBatch()
+	defer batch.Destroy()
+	batch.SingleDeleteCF(cf, key)
+	return db.Write(opts, batch)
+}
+
 // DeleteCF removes the data associated with the key from the database and column family.
 func (db *DB) DeleteCF(opts *WriteOptions, cf *ColumnFamilyHandle, key []byte) error {
 	var (
@@ -441,7 +550,7 @@ func (db *DB) DeleteCF(opts *WriteOptions, cf *ColumnFamilyHandle, key []byte) e
 	C.rocksdb_delete_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -456,7 +565,7 @@ func (db *DB) Merge(opts *WriteOptions, key []byte, value []byte) error {
 	C.rocksdb_merge(db.c, opts.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -472,7 +581,7 @@ func (db *DB) MergeCF(opts *WriteOptions, cf *ColumnFamilyHandle, key []byte, va
 	C.rocksdb_merge_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -483,7 +592,18 @@ func (db *DB) Write(opts *WriteOptions, batch *WriteBatch) error {
 	C.rocksdb_write(db.c, opts.c, batch.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// WriteWithIndex writes a WriteBatchWithIndex to the database
+func (db *DB) WriteWithIndex(opts *WriteOptions, batch *WriteBatchWithIndex) error {
+	var cErr *C.char
+	C.rocksdb_write_writebatch_wi(db.c, opts.c, batch.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -532,6 +652,67 @@ func (db *DB) GetPropertyCF(propName string, cf *ColumnFamilyHandle) string {
 	return C.GoString(cValue)
 }
 
+// GetIntProperty returns the value of a database property with an integer
+// value. The second return value is false if the property is unknown or has
+// no integer value.
+func (db *DB) GetIntProperty(propName Property) (uint64, bool) {
+	cProp := C.CString(string(propName))
+	defer C.free(unsafe.Pointer(cProp))
+	var cValue C.uint64_t
+	ok := C.rocksdb_property_int(db.c, cProp, &cValue) == 0
+	return uint64(cValue), ok
+}
+
+// GetIntPropertyCF returns the value of a column family property with an
+// integer value. The second return value is false if the property is unknown
+// or has no integer value.
+func (db *DB) GetIntPropertyCF(propName Property, cf *ColumnFamilyHandle) (uint64, bool) {
+	cProp := C.CString(string(propName))
+	defer C.free(unsafe.Pointer(cProp))
+	var cValue C.uint64_t
+	ok := C.rocksdb_property_int_cf(db.c, cf.c, cProp, &cValue) == 0
+	return uint64(cValue), ok
+}
+
+// GetMapProperty returns the value of a database property with a map value,
+// such as PropertyCFStats. It returns nil if the property is unknown or has
+// no map value.
+func (db *DB) GetMapProperty(propName Property) map[string]string {
+	return db.getMapProperty(propName, nil)
+}
+
+// GetMapPropertyCF returns the value of a column family property with a map
+// value, such as PropertyCFStats. It returns nil if the property is unknown
+// or has no map value.
+func (db *DB) GetMapPropertyCF(propName Property, cf *ColumnFamilyHandle) map[string]string {
+	return db.getMapProperty(propName, cf.c)
+}
+
+func (db *DB) getMapProperty(propName Property, cf *C.rocksdb_column_family_handle_t) map[string]string {
+	var (
+		cKeys   **C.char
+		cValues **C.char
+		cSize   C.size_t
+		cProp   = C.CString(string(propName))
+	)
+	defer C.free(unsafe.Pointer(cProp))
+	if C.gorocksdb_property_map(db.c, cf, cProp, &cKeys, &cValues, &cSize) == 0 {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(cKeys))
+	defer C.free(unsafe.Pointer(cValues))
+
+	keys := charSlice(cKeys, C.int(cSize))
+	values := charSlice(cValues, C.int(cSize))
+	props := make(map[string]string, int(cSize))
+	for i := range keys {
+		props[C.GoString(keys[i])] = C.GoString(values[i])
+		C.free(unsafe.Pointer(keys[i]))
+		C.free(unsafe.Pointer(values[i]))
+	}
+	return props
+}
+
 // CreateColumnFamily create a new column family.
 func (db *DB) CreateColumnFamily(opts *Options, name string) (*ColumnFamilyHandle, error) {
 	var (
@@ -542,7 +723,26 @@ func (db *DB) CreateColumnFamily(opts *Options, name string) (*ColumnFamilyHandl
 	cHandle := C.rocksdb_create_column_family(db.c, opts.c, cName, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return NewNativeColumnFamilyHandle(cHandle, name), nil
+}
+
+// CreateColumnFamilyWithImport creates a new column family with the table
+// files exported by Checkpoint.ExportColumnFamily. The files are copied into
+// the database, the export directory can be deleted afterwards.
+func (db *DB) CreateColumnFamilyWithImport(opts *Options, name string, metadata *ExportImportFilesMetadata) (*ColumnFamilyHandle, error) {
+	var (
+		cErr  *C.char
+		cName = C.CString(name)
+	)
+	defer C.free(unsafe.Pointer(cName))
+	cMetadata := metadata.toC()
+	defer C.gorocksdb_export_import_files_metadata_destroy(cMetadata)
+	cHandle := C.gorocksdb_create_column_family_with_import(db.c, opts.c, cName, cMetadata, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return NewNativeColumnFamilyHandle(cHandle, name), nil
 }
@@ -553,7 +753,7 @@ func (db *DB) DropColumnFamily(c *ColumnFamilyHandle) error {
 	C.rocksdb_drop_column_family(db.c, c.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -629,13 +829,20 @@ func (db *DB) GetApproximateSizesCF(cf *ColumnFamilyHandle, ranges []Range) []ui
 
 // LiveFileMetadata is a metadata which is associated with each SST file.
 type LiveFileMetadata struct {
-	Name        string
-	Level       int
-	Size        int64
-	SmallestKey []byte
-	LargestKey  []byte
-	Entries     uint64
-	Deletions   uint64
+	Name             string
+	ColumnFamilyName string
+	// DBPath is the directory holding the file, one of the paths set with
+	// Options.SetDBPaths.
+	DBPath         string
+	Level          int
+	Size           int64
+	SmallestKey    []byte
+	LargestKey     []byte
+	SmallestSeqno  uint64
+	LargestSeqno   uint64
+	Entries        uint64
+	Deletions      uint64
+	BeingCompacted bool
 }
 
 // GetLiveFilesMetaData returns a list of all table files with their
@@ -653,6 +860,11 @@ func (db *DB) GetLiveFilesMetaData() []LiveFileMetadata {
 		liveFile.Size = int64(C.rocksdb_livefiles_size(lf, i))
 		liveFile.Entries = uint64(C.rocksdb_livefiles_entries(lf, i))
 		liveFile.Deletions = uint64(C.rocksdb_livefiles_deletions(lf, i))
+		liveFile.ColumnFamilyName = C.GoString(C.gorocksdb_livefiles_column_family_name(lf, i))
+		liveFile.DBPath = C.GoString(C.gorocksdb_livefiles_db_path(lf, i))
+		liveFile.SmallestSeqno = uint64(C.gorocksdb_livefiles_smallest_seqno(lf, i))
+		liveFile.LargestSeqno = uint64(C.gorocksdb_livefiles_largest_seqno(lf, i))
+		liveFile.BeingCompacted = C.gorocksdb_livefiles_being_compacted(lf, i) != 0
 
 		var cSize C.size_t
 		key := C.rocksdb_livefiles_smallestkey(lf, i, &cSize)
@@ -665,6 +877,84 @@ func (db *DB) GetLiveFilesMetaData() []LiveFileMetadata {
 	return liveFiles
 }
 
+// ColumnFamilyMetadata describes the table files of a column family, level
+// by level.
+type ColumnFamilyMetadata struct {
+	Name string
+	// Size is the total size of the table files in bytes.
+	Size      int64
+	FileCount int
+	Levels    []LevelMetadata
+}
+
+// LevelMetadata describes the table files of a level.
+type LevelMetadata struct {
+	Level int
+	// Size is the total size of the table files of the level in bytes.
+	Size  int64
+	Files []SstFileMetadata
+}
+
+// SstFileMetadata is the metadata of a table file of a level.
+type SstFileMetadata struct {
+	Name           string
+	DBPath         string
+	Size           int64
+	SmallestKey    []byte
+	LargestKey     []byte
+	SmallestSeqno  uint64
+	LargestSeqno   uint64
+	Entries        uint64
+	Deletions      uint64
+	BeingCompacted bool
+}
+
+// GetColumnFamilyMetaData returns the metadata of the default column family.
+func (db *DB) GetColumnFamilyMetaData() *ColumnFamilyMetadata {
+	return db.getColumnFamilyMetaData(nil)
+}
+
+// GetColumnFamilyMetaDataCF returns the metadata of the column family.
+func (db *DB) GetColumnFamilyMetaDataCF(cf *ColumnFamilyHandle) *ColumnFamilyMetadata {
+	return db.getColumnFamilyMetaData(cf.c)
+}
+
+func (db *DB) getColumnFamilyMetaData(cf *C.rocksdb_column_family_handle_t) *ColumnFamilyMetadata {
+	cMeta := C.gorocksdb_get_column_family_metadata(db.c, cf)
+	defer C.gorocksdb_column_family_metadata_destroy(cMeta)
+
+	meta := &ColumnFamilyMetadata{
+		Name:      C.GoString(C.gorocksdb_column_family_metadata_name(cMeta)),
+		Size:      int64(C.gorocksdb_column_family_metadata_size(cMeta)),
+		FileCount: int(C.gorocksdb_column_family_metadata_file_count(cMeta)),
+		Levels:    make([]LevelMetadata, int(C.gorocksdb_column_family_metadata_level_count(cMeta))),
+	}
+	for i := range meta.Levels {
+		cLevel := C.int(i)
+		level := &meta.Levels[i]
+		level.Level = int(C.gorocksdb_column_family_metadata_level(cMeta, cLevel))
+		level.Size = int64(C.gorocksdb_column_family_metadata_level_size(cMeta, cLevel))
+		level.Files = make([]SstFileMetadata, int(C.gorocksdb_column_family_metadata_level_file_count(cMeta, cLevel)))
+		for j := range level.Files {
+			var cFile C.gorocksdb_sst_file_metadata_t
+			C.gorocksdb_column_family_metadata_level_file(cMeta, cLevel, C.int(j), &cFile)
+			level.Files[j] = SstFileMetadata{
+				Name:           C.GoString(cFile.name),
+				DBPath:         C.GoString(cFile.db_path),
+				Size:           int64(cFile.size),
+				SmallestKey:    C.GoBytes(unsafe.Pointer(cFile.smallest_key), C.int(cFile.smallest_key_len)),
+				LargestKey:     C.GoBytes(unsafe.Pointer(cFile.largest_key), C.int(cFile.largest_key_len)),
+				SmallestSeqno:  uint64(cFile.smallest_seqno),
+				LargestSeqno:   uint64(cFile.largest_seqno),
+				Entries:        uint64(cFile.num_entries),
+				Deletions:      uint64(cFile.num_deletions),
+				BeingCompacted: cFile.being_compacted != 0,
+			}
+		}
+	}
+	return meta
+}
+
 // CompactRange runs a manual compaction on the Range of keys given. This is
 // not likely to be needed for typical usage.
 func (db *DB) CompactRange(r Range) {
@@ -681,24 +971,145 @@ func (db *DB) CompactRangeCF(cf *ColumnFamilyHandle, r Range) {
 	C.rocksdb_compact_range_cf(db.c, cf.c, cStart, C.size_t(len(r.Start)), cLimit, C.size_t(len(r.Limit)))
 }
 
+// CompactRangeOpt runs a manual compaction on the Range of keys given with
+// the given options.
+func (db *DB) CompactRangeOpt(opts *CompactRangeOptions, r Range) {
+	cStart := byteToChar(r.Start)
+	cLimit := byteToChar(r.Limit)
+	C.rocksdb_compact_range_opt(db.c, opts.c, cStart, C.size_t(len(r.Start)), cLimit, C.size_t(len(r.Limit)))
+}
+
+// CompactRangeCFOpt runs a manual compaction on the Range of keys given on
+// the given column family with the given options.
+func (db *DB) CompactRangeCFOpt(cf *ColumnFamilyHandle, opts *CompactRangeOptions, r Range) {
+	cStart := byteToChar(r.Start)
+	cLimit := byteToChar(r.Limit)
+	C.rocksdb_compact_range_cf_opt(db.c, cf.c, opts.c, cStart, C.size_t(len(r.Start)), cLimit, C.size_t(len(r.Limit)))
+}
+
+// CompactFiles compacts the given table files of the column family cf, or
+// of the default column family if cf is nil, into outputLevel. The file
+// names are the names returned by GetLiveFilesMetaData or
+// GetColumnFamilyMetaData.
+func (db *DB) CompactFiles(cf *ColumnFamilyHandle, fileNames []string, outputLevel int) error {
+	if len(fileNames) == 0 {
+		return errors.New("must provide the files to compact")
+	}
+	cFileNames := make([]*C.char, len(fileNames))
+	for i, s := range fileNames {
+		cFileNames[i] = C.CString(s)
+	}
+	defer func() {
+		for _, s := range cFileNames {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	var cCF *C.rocksdb_column_family_handle_t
+	if cf != nil {
+		cCF = cf.c
+	}
+	var cErr *C.char
+	C.gorocksdb_compact_files(db.c, cCF, &cFileNames[0], C.size_t(len(fileNames)), C.int(outputLevel), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
 // Flush triggers a manuel flush for the database.
 func (db *DB) Flush(opts *FlushOptions) error {
 	var cErr *C.char
 	C.rocksdb_flush(db.c, opts.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
 
+// GetPropertiesOfAllTables returns the table properties of all table files
+// of the default column family, by file path.
+func (db *DB) GetPropertiesOfAllTables() (map[string]*TableProperties, error) {
+	return db.getPropertiesOfAllTables(nil)
+}
+
+// GetPropertiesOfAllTablesCF returns the table properties of all table files
+// of the column family, by file path.
+func (db *DB) GetPropertiesOfAllTablesCF(cf *ColumnFamilyHandle) (map[string]*TableProperties, error) {
+	return db.getPropertiesOfAllTables(cf.c)
+}
+
+func (db *DB) getPropertiesOfAllTables(cf *C.rocksdb_column_family_handle_t) (map[string]*TableProperties, error) {
+	var cErr *C.char
+	cCollection := C.gorocksdb_get_properties_of_all_tables(db.c, cf, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return tablePropertiesCollection(cCollection), nil
+}
+
+// GetPropertiesOfTablesInRange returns the table properties of the table
+// files of the default column family which overlap with the key ranges, by
+// file path.
+func (db *DB) GetPropertiesOfTablesInRange(ranges []Range) (map[string]*TableProperties, error) {
+	return db.getPropertiesOfTablesInRange(nil, ranges)
+}
+
+// GetPropertiesOfTablesInRangeCF returns the table properties of the table
+// files of the column family which overlap with the key ranges, by file path.
+func (db *DB) GetPropertiesOfTablesInRangeCF(cf *ColumnFamilyHandle, ranges []Range) (map[string]*TableProperties, error) {
+	return db.getPropertiesOfTablesInRange(cf.c, ranges)
+}
+
+func (db *DB) getPropertiesOfTablesInRange(cf *C.rocksdb_column_family_handle_t, ranges []Range) (map[string]*TableProperties, error) {
+	if len(ranges) == 0 {
+		return map[string]*TableProperties{}, nil
+	}
+
+	cStarts := make([]*C.char, len(ranges))
+	cLimits := make([]*C.char, len(ranges))
+	cStartLens := make([]C.size_t, len(ranges))
+	cLimitLens := make([]C.size_t, len(ranges))
+	for i, r := range ranges {
+		cStarts[i] = C.CString(string(r.Start))
+		cStartLens[i] = C.size_t(len(r.Start))
+		cLimits[i] = C.CString(string(r.Limit))
+		cLimitLens[i] = C.size_t(len(r.Limit))
+	}
+	defer func() {
+		for i := range ranges {
+			C.free(unsafe.Pointer(cStarts[i]))
+			C.free(unsafe.Pointer(cLimits[i]))
+		}
+	}()
+
+	var cErr *C.char
+	cCollection := C.gorocksdb_get_properties_of_tables_in_range(
+		db.c,
+		cf,
+		C.int(len(ranges)),
+		&cStarts[0],
+		&cStartLens[0],
+		&cLimits[0],
+		&cLimitLens[0],
+		&cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return tablePropertiesCollection(cCollection), nil
+}
+
 // DisableFileDeletions disables file deletions and should be used when backup the database.
 func (db *DB) DisableFileDeletions() error {
 	var cErr *C.char
 	C.rocksdb_disable_file_deletions(db.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -709,7 +1120,7 @@ func (db *DB) EnableFileDeletions(force bool) error {
 	C.rocksdb_enable_file_deletions(db.c, boolToChar(force), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -723,6 +1134,97 @@ func (db *DB) DeleteFile(name string) {
 	C.rocksdb_delete_file(db.c, cName)
 }
 
+// DeleteFilesInRange deletes the table files of the column family cf, or of
+// the default column family if cf is nil, whose keys are all within one of
+// the ranges. A nil Start or Limit leaves a range unbounded on that side. If
+// includeEnd is true, the Limit of the ranges is inclusive. Files in level 0
+// are never deleted.
+//
+// The space of the files is reclaimed immediately, but the keys in files
+// overlapping the edges of the ranges are not deleted. Snapshots do not
+// protect the keys of deleted files.
+func (db *DB) DeleteFilesInRange(cf *ColumnFamilyHandle, ranges []Range, includeEnd bool) error {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	cStarts := make([]*C.char, len(ranges))
+	cLimits := make([]*C.char, len(ranges))
+	cStartLens := make([]C.size_t, len(ranges))
+	cLimitLens := make([]C.size_t, len(ranges))
+	for i, r := range ranges {
+		if r.Start != nil {
+			cStarts[i] = C.CString(string(r.Start))
+			cStartLens[i] = C.size_t(len(r.Start))
+		}
+		if r.Limit != nil {
+			cLimits[i] = C.CString(string(r.Limit))
+			cLimitLens[i] = C.size_t(len(r.Limit))
+		}
+	}
+	defer func() {
+		for i := range ranges {
+			C.free(unsafe.Pointer(cStarts[i]))
+			C.free(unsafe.Pointer(cLimits[i]))
+		}
+	}()
+
+	var cCF *C.rocksdb_column_family_handle_t
+	if cf != nil {
+		cCF = cf.c
+	}
+	var cErr *C.char
+	C.gorocksdb_delete_files_in_ranges(
+		db.c,
+		cCF,
+		C.int(len(ranges)),
+		&cStarts[0],
+		&cStartLens[0],
+		&cLimits[0],
+		&cLimitLens[0],
+		boolToChar(includeEnd),
+		&cErr,
+	)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// PurgeRange deletes all keys of the column family cf, or of the default
+// column family if cf is nil, in the range [r.Start, r.Limit) and reclaims
+// their space. The table files fully contained in the range are deleted
+// first, then the remaining keys are deleted with a range deletion and the
+// range is compacted. A nil Start starts the range at the first key, Limit
+// must not be nil.
+//
+// Like DeleteFilesInRange, snapshots do not protect the purged keys.
+func (db *DB) PurgeRange(opts *WriteOptions, cf *ColumnFamilyHandle, r Range) error {
+	if r.Limit == nil {
+		return errors.New("must provide the limit of the range to purge")
+	}
+	if err := db.DeleteFilesInRange(cf, []Range{r}, false); err != nil {
+		return err
+	}
+	batch := NewWriteBatch()
+	defer batch.Destroy()
+	if cf == nil {
+		batch.DeleteRange(r.Start, r.Limit)
+	} else {
+		batch.DeleteRangeCF(cf, r.Start, r.Limit)
+	}
+	if err := db.Write(opts, batch); err != nil {
+		return err
+	}
+	if cf == nil {
+		db.CompactRange(r)
+	} else {
+		db.CompactRangeCF(cf, r)
+	}
+	return nil
+}
+
 // IngestExternalFile loads a list of external SST files.
 func (db *DB) IngestExternalFile(filePaths []string, opts *IngestExternalFileOptions) error {
 	cFilePaths := make([]*C.char, len(filePaths))
@@ -747,7 +1249,7 @@ func (db *DB) IngestExternalFile(filePaths []string, opts *IngestExternalFileOpt
 
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -777,11 +1279,101 @@ func (db *DB) IngestExternalFileCF(handle *ColumnFamilyHandle, filePaths []strin
 
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// IngestExternalFileArg describes the files to ingest into a column family
+// with IngestExternalFiles.
+type IngestExternalFileArg struct {
+	ColumnFamily *ColumnFamilyHandle
+	FilePaths    []string
+	Options      *IngestExternalFileOptions
+}
+
+// IngestExternalFiles loads lists of external SST files into several column
+// families atomically: either the files of all column families are ingested
+// or none of them.
+func (db *DB) IngestExternalFiles(args []IngestExternalFileArg) error {
+	if len(args) == 0 {
+		return nil
+	}
+	var (
+		cCFs       = make([]*C.rocksdb_column_family_handle_t, len(args))
+		cOpts      = make([]*C.rocksdb_ingestexternalfileoptions_t, len(args))
+		cNumFiles  = make([]C.size_t, len(args))
+		cFilePaths []*C.char
+	)
+	for i, arg := range args {
+		cCFs[i] = arg.ColumnFamily.c
+		cOpts[i] = arg.Options.c
+		cNumFiles[i] = C.size_t(len(arg.FilePaths))
+		for _, s := range arg.FilePaths {
+			cFilePaths = append(cFilePaths, C.CString(s))
+		}
+	}
+	defer func() {
+		for _, s := range cFilePaths {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+	if len(cFilePaths) == 0 {
+		return errors.New("must provide the files to ingest")
+	}
+
+	var cErr *C.char
+
+	C.gorocksdb_ingest_external_files(
+		db.c,
+		C.int(len(args)),
+		&cCFs[0],
+		&cOpts[0],
+		&cNumFiles[0],
+		&cFilePaths[0],
+		&cErr,
+	)
+
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// TryCatchUpWithPrimary makes a secondary instance catch up with the changes
+// of the primary, by replaying its MANIFEST and write ahead logs. It is an
+// error to call it on a database which was not opened as a secondary.
+func (db *DB) TryCatchUpWithPrimary() error {
+	var cErr *C.char
+	C.rocksdb_try_catch_up_with_primary(db.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
 
+// GetLatestSequenceNumber returns the sequence number of the most recent
+// transaction.
+func (db *DB) GetLatestSequenceNumber() uint64 {
+	return uint64(C.rocksdb_get_latest_sequence_number(db.c))
+}
+
+// GetUpdatesSince returns a WALIterator over the updates made to the database
+// starting from the batch that contains the sequence number seqNumber.
+// Updates are only available as long as their log files are kept, see
+// Options.SetWALTtlSeconds and Options.SetWalSizeLimitMb.
+func (db *DB) GetUpdatesSince(seqNumber uint64) (*WALIterator, error) {
+	var cErr *C.char
+	cIter := C.rocksdb_get_updates_since(db.c, C.uint64_t(seqNumber), nil, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return NewNativeWALIterator(unsafe.Pointer(cIter)), nil
+}
+
 // NewCheckpoint creates a new Checkpoint for this db.
 func (db *DB) NewCheckpoint() (*Checkpoint, error) {
 	var (
@@ -792,7 +1384,7 @@ func (db *DB) NewCheckpoint() (*Checkpoint, error) {
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 
 	return NewNativeCheckpoint(cCheckpoint), nil
@@ -814,7 +1406,7 @@ func DestroyDb(name string, opts *Options) error {
 	C.rocksdb_destroy_db(opts.c, cName, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -829,7 +1421,7 @@ func RepairDb(name string, opts *Options) error {
 	C.rocksdb_repair_db(opts.c, cName, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
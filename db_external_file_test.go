@@ -55,3 +55,60 @@ func TestExternalFile(t *testing.T) {
 	ensure.Nil(t, err)
 	ensure.DeepEqual(t, v4.Data(), []byte("dddValue"))
 }
+
+func newTestSSTFile(t *testing.T, kvs ...string) string {
+	w := NewSSTFileWriter(NewDefaultEnvOptions(), NewDefaultOptions())
+	defer w.Destroy()
+
+	f, err := ioutil.TempFile("", "sst-file-test")
+	ensure.Nil(t, err)
+	ensure.Nil(t, f.Close())
+
+	ensure.Nil(t, w.Open(f.Name()))
+	for i := 0; i < len(kvs); i += 2 {
+		ensure.Nil(t, w.Add([]byte(kvs[i]), []byte(kvs[i+1])))
+	}
+	ensure.Nil(t, w.Finish())
+	return f.Name()
+}
+
+func TestIngestExternalFiles(t *testing.T) {
+	db, cfh, cleanup := newTestDBCF(t, "TestIngestExternalFiles")
+	defer cleanup()
+
+	dataFile := newTestSSTFile(t, "aaa", "data1", "bbb", "data2")
+	defer os.Remove(dataFile)
+	indexFile := newTestSSTFile(t, "idx1", "aaa")
+	defer os.Remove(indexFile)
+
+	ingestOpts := NewDefaultIngestExternalFileOptions()
+	defer ingestOpts.Destroy()
+	ingestOpts.SetVerifyChecksumsBeforeIngest(true)
+	ingestOpts.SetWriteGlobalSeqno(false)
+	ingestOpts.SetFailIfNotBottommostLevel(false)
+
+	// a missing file fails the whole ingestion
+	err := db.IngestExternalFiles([]IngestExternalFileArg{
+		{ColumnFamily: cfh[0], FilePaths: []string{dataFile}, Options: ingestOpts},
+		{ColumnFamily: cfh[1], FilePaths: []string{indexFile + "-missing"}, Options: ingestOpts},
+	})
+	ensure.NotNil(t, err)
+	ro := NewDefaultReadOptions()
+	v, err := db.GetBytes(ro, []byte("aaa"))
+	ensure.Nil(t, err)
+	ensure.True(t, v == nil)
+
+	err = db.IngestExternalFiles([]IngestExternalFileArg{
+		{ColumnFamily: cfh[0], FilePaths: []string{dataFile}, Options: ingestOpts},
+		{ColumnFamily: cfh[1], FilePaths: []string{indexFile}, Options: ingestOpts},
+	})
+	ensure.Nil(t, err)
+
+	v, err = db.GetBytes(ro, []byte("bbb"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v, []byte("data2"))
+	idx, err := db.GetCF(ro, cfh[1], []byte("idx1"))
+	ensure.Nil(t, err)
+	defer idx.Free()
+	ensure.DeepEqual(t, idx.Data(), []byte("aaa"))
+}
@@ -209,3 +209,152 @@ func TestDBDeleteRange(t *testing.T) {
 	ensure.Nil(t, err)
 	ensure.True(t, val3.Data() == nil)
 }
+
+func TestDBGetIntProperty(t *testing.T) {
+	db := newTestDB(t, "TestDBGetIntProperty", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("hello"), []byte("world")))
+
+	numKeys, ok := db.GetIntProperty(PropertyEstimateNumKeys)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, numKeys, uint64(1))
+
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ensure.Nil(t, db.Flush(fo))
+	numFiles, ok := db.GetIntProperty(PropertyNumFilesAtLevel(0))
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, numFiles, uint64(1))
+
+	_, ok = db.GetIntProperty(PropertyStats)
+	ensure.False(t, ok)
+	_, ok = db.GetIntProperty("rocksdb.unknown")
+	ensure.False(t, ok)
+}
+
+func TestDBGetMapProperty(t *testing.T) {
+	db := newTestDB(t, "TestDBGetMapProperty", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("hello"), []byte("world")))
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ensure.Nil(t, db.Flush(fo))
+
+	stats := db.GetMapProperty(PropertyCFStats)
+	ensure.NotNil(t, stats)
+	_, ok := stats["compaction.L0.NumFiles"]
+	ensure.True(t, ok)
+
+	ensure.True(t, db.GetMapProperty("rocksdb.unknown") == nil)
+}
+
+func TestDBGetLiveFilesMetaData(t *testing.T) {
+	db := newTestDB(t, "TestDBGetLiveFilesMetaData", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("value")))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("value")))
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+	liveFiles := db.GetLiveFilesMetaData()
+	ensure.DeepEqual(t, len(liveFiles), 1)
+	ensure.DeepEqual(t, liveFiles[0].ColumnFamilyName, "default")
+	ensure.DeepEqual(t, liveFiles[0].DBPath, db.Name())
+	ensure.DeepEqual(t, liveFiles[0].SmallestSeqno, uint64(1))
+	ensure.DeepEqual(t, liveFiles[0].LargestSeqno, uint64(2))
+	ensure.False(t, liveFiles[0].BeingCompacted)
+}
+
+func TestDBCompactRangeOpt(t *testing.T) {
+	db := newTestDB(t, "TestDBCompactRangeOpt", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("value")))
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+	opts := NewDefaultCompactRangeOptions()
+	defer opts.Destroy()
+	opts.SetExclusiveManualCompaction(true)
+	opts.SetChangeLevel(true)
+	opts.SetTargetLevel(3)
+	opts.SetBottommostLevelCompaction(BottommostLevelCompactionForce)
+	opts.SetTargetPathID(0)
+	db.CompactRangeOpt(opts, Range{})
+
+	liveFiles := db.GetLiveFilesMetaData()
+	ensure.DeepEqual(t, len(liveFiles), 1)
+	ensure.DeepEqual(t, liveFiles[0].Level, 3)
+}
+
+func TestDBCompactFiles(t *testing.T) {
+	db := newTestDB(t, "TestDBCompactFiles", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("value")))
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("value")))
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+	var fileNames []string
+	for _, lf := range db.GetLiveFilesMetaData() {
+		ensure.DeepEqual(t, lf.Level, 0)
+		fileNames = append(fileNames, lf.Name)
+	}
+	ensure.DeepEqual(t, len(fileNames), 2)
+	ensure.Nil(t, db.CompactFiles(nil, fileNames, 1))
+
+	liveFiles := db.GetLiveFilesMetaData()
+	ensure.DeepEqual(t, len(liveFiles), 1)
+	ensure.DeepEqual(t, liveFiles[0].Level, 1)
+
+	ensure.NotNil(t, db.CompactFiles(nil, nil, 1))
+}
+
+func TestDBDeleteFilesInRange(t *testing.T) {
+	db := newTestDB(t, "TestDBDeleteFilesInRange", nil)
+	defer db.Close()
+
+	// write a table file in level 1 for each group of keys
+	wo := NewDefaultWriteOptions()
+	for _, keys := range [][]string{{"a1", "a2"}, {"b1", "b2"}, {"b3", "c1"}} {
+		for _, k := range keys {
+			ensure.Nil(t, db.Put(wo, []byte(k), []byte("value")))
+		}
+		ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+		for _, lf := range db.GetLiveFilesMetaData() {
+			if lf.Level == 0 {
+				ensure.Nil(t, db.CompactFiles(nil, []string{lf.Name}, 1))
+			}
+		}
+	}
+	ensure.DeepEqual(t, len(db.GetLiveFilesMetaData()), 3)
+
+	ro := NewDefaultReadOptions()
+	ensure.Nil(t, db.DeleteFilesInRange(nil, []Range{{Start: nil, Limit: []byte("a9")}}, false))
+	ensure.DeepEqual(t, len(db.GetLiveFilesMetaData()), 2)
+	v, err := db.GetBytes(ro, []byte("a1"))
+	ensure.Nil(t, err)
+	ensure.True(t, v == nil)
+
+	// b3 shares its file with c1, so it is deleted by the range deletion
+	ensure.Nil(t, db.PurgeRange(wo, nil, Range{Start: []byte("b"), Limit: []byte("c")}))
+	for _, k := range []string{"b1", "b2", "b3"} {
+		v, err = db.GetBytes(ro, []byte(k))
+		ensure.Nil(t, err)
+		ensure.True(t, v == nil)
+	}
+	v, err = db.GetBytes(ro, []byte("c1"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v, []byte("value"))
+
+	ensure.NotNil(t, db.PurgeRange(wo, nil, Range{Start: []byte("c")}))
+}
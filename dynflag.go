@@ -2,5 +2,6 @@
 
 package gorocksdb
 
+// #cgo CXXFLAGS: -std=c++17
 // #cgo LDFLAGS: -lrocksdb -lstdc++ -lm -lz -lbz2 -lsnappy
 import "C"
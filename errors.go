@@ -0,0 +1,201 @@
+package gorocksdb
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrorCode is the code of a RocksDB status.
+type ErrorCode int
+
+// Error codes, in the order used by RocksDB.
+const (
+	CodeOK                  = ErrorCode(0)
+	CodeNotFound            = ErrorCode(1)
+	CodeCorruption          = ErrorCode(2)
+	CodeNotSupported        = ErrorCode(3)
+	CodeInvalidArgument     = ErrorCode(4)
+	CodeIOError             = ErrorCode(5)
+	CodeMergeInProgress     = ErrorCode(6)
+	CodeIncomplete          = ErrorCode(7)
+	CodeShutdownInProgress  = ErrorCode(8)
+	CodeTimedOut            = ErrorCode(9)
+	CodeAborted             = ErrorCode(10)
+	CodeBusy                = ErrorCode(11)
+	CodeExpired             = ErrorCode(12)
+	CodeTryAgain            = ErrorCode(13)
+	CodeCompactionTooLarge  = ErrorCode(14)
+	CodeColumnFamilyDropped = ErrorCode(15)
+	// CodeUnknown is used for messages which could not be parsed.
+	CodeUnknown = ErrorCode(-1)
+)
+
+// ErrorSubCode refines the code of a RocksDB status.
+type ErrorSubCode int
+
+// Error sub codes, in the order used by RocksDB.
+const (
+	SubCodeNone                              = ErrorSubCode(0)
+	SubCodeMutexTimeout                      = ErrorSubCode(1)
+	SubCodeLockTimeout                       = ErrorSubCode(2)
+	SubCodeLockLimit                         = ErrorSubCode(3)
+	SubCodeNoSpace                           = ErrorSubCode(4)
+	SubCodeDeadlock                          = ErrorSubCode(5)
+	SubCodeStaleFile                         = ErrorSubCode(6)
+	SubCodeMemoryLimit                       = ErrorSubCode(7)
+	SubCodeSpaceLimit                        = ErrorSubCode(8)
+	SubCodePathNotFound                      = ErrorSubCode(9)
+	SubCodeMergeOperandsInsufficientCapacity = ErrorSubCode(10)
+	SubCodeManualCompactionPaused            = ErrorSubCode(11)
+)
+
+// Message prefixes written by Status::ToString, indexed by code.
+var errorCodePrefixes = map[ErrorCode]string{
+	CodeNotFound:            "NotFound",
+	CodeCorruption:          "Corruption",
+	CodeNotSupported:        "Not implemented",
+	CodeInvalidArgument:     "Invalid argument",
+	CodeIOError:             "IO error",
+	CodeMergeInProgress:     "Merge in progress",
+	CodeIncomplete:          "Result incomplete",
+	CodeShutdownInProgress:  "Shutdown in progress",
+	CodeTimedOut:            "Operation timed out",
+	CodeAborted:             "Operation aborted",
+	CodeBusy:                "Resource busy",
+	CodeExpired:             "Operation expired",
+	CodeTryAgain:            "Operation failed. Try again.",
+	CodeCompactionTooLarge:  "Compaction too large",
+	CodeColumnFamilyDropped: "Column family dropped",
+}
+
+// Messages written by Status::ToString for sub codes.
+var errorSubCodeMessages = map[ErrorSubCode]string{
+	SubCodeMutexTimeout:                      "Timeout Acquiring Mutex",
+	SubCodeLockTimeout:                       "Timeout waiting to lock key",
+	SubCodeLockLimit:                         "Failed to acquire lock due to max_num_locks limit",
+	SubCodeNoSpace:                           "No space left on device",
+	SubCodeDeadlock:                          "Deadlock",
+	SubCodeStaleFile:                         "Stale file handle",
+	SubCodeMemoryLimit:                       "Memory limit reached",
+	SubCodeSpaceLimit:                        "Space limit reached",
+	SubCodePathNotFound:                      "No such file or directory",
+	SubCodeMergeOperandsInsufficientCapacity: "Insufficient capacity for merge operands",
+	SubCodeManualCompactionPaused:            "Manual compaction paused",
+}
+
+// Error is an error returned by RocksDB. It can be matched against the
+// sentinel errors of this package using errors.Is:
+//
+//	if err := txn.Commit(); errors.Is(err, gorocksdb.ErrBusy) {
+//	    // retry the transaction
+//	}
+type Error struct {
+	Code    ErrorCode
+	SubCode ErrorSubCode
+	// Message holds the details of the error without code and sub code.
+	Message string
+
+	status string
+}
+
+// Sentinel errors matching errors returned by RocksDB with the same code.
+var (
+	ErrNotFound            = &Error{Code: CodeNotFound}
+	ErrCorruption          = &Error{Code: CodeCorruption}
+	ErrNotSupported        = &Error{Code: CodeNotSupported}
+	ErrInvalidArgument     = &Error{Code: CodeInvalidArgument}
+	ErrIOError             = &Error{Code: CodeIOError}
+	ErrMergeInProgress     = &Error{Code: CodeMergeInProgress}
+	ErrIncomplete          = &Error{Code: CodeIncomplete}
+	ErrShutdownInProgress  = &Error{Code: CodeShutdownInProgress}
+	ErrTimedOut            = &Error{Code: CodeTimedOut}
+	ErrAborted             = &Error{Code: CodeAborted}
+	ErrBusy                = &Error{Code: CodeBusy}
+	ErrExpired             = &Error{Code: CodeExpired}
+	ErrTryAgain            = &Error{Code: CodeTryAgain}
+	ErrCompactionTooLarge  = &Error{Code: CodeCompactionTooLarge}
+	ErrColumnFamilyDropped = &Error{Code: CodeColumnFamilyDropped}
+)
+
+// Sentinel errors matching errors returned by RocksDB with the same code
+// and sub code.
+var (
+	ErrLockTimeout = &Error{Code: CodeTimedOut, SubCode: SubCodeLockTimeout}
+	ErrDeadlock    = &Error{Code: CodeBusy, SubCode: SubCodeDeadlock}
+	ErrNoSpace     = &Error{Code: CodeIOError, SubCode: SubCodeNoSpace}
+)
+
+// ErrTransactionConflict is matched, using errors.Is, by the error returned
+// from Transaction.Commit when another writer modified a key tracked by the
+// transaction. This is how optimistic transactions report conflicts; the
+// transaction should be rolled back and may then be retried.
+//
+// It matches errors with the code CodeBusy without a sub code and errors with
+// the code CodeTryAgain, but not deadlocks or lock limit errors.
+var ErrTransactionConflict = errors.New("transaction conflict")
+
+// Error returns the message as reported by RocksDB.
+func (e *Error) Error() string {
+	if e.status != "" {
+		return e.status
+	}
+	parts := make([]string, 0, 3)
+	if prefix, ok := errorCodePrefixes[e.Code]; ok {
+		parts = append(parts, prefix)
+	}
+	if msg, ok := errorSubCodeMessages[e.SubCode]; ok {
+		parts = append(parts, msg)
+	}
+	if e.Message != "" {
+		parts = append(parts, e.Message)
+	}
+	return strings.Join(parts, ": ")
+}
+
+// Is reports whether the error matches target. An *Error target matches when
+// the codes are equal and, if the target has one, the sub codes are equal.
+func (e *Error) Is(target error) bool {
+	if target == ErrTransactionConflict {
+		return (e.Code == CodeBusy && e.SubCode == SubCodeNone) || e.Code == CodeTryAgain
+	}
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code && (t.SubCode == SubCodeNone || t.SubCode == e.SubCode)
+}
+
+// ParseError parses a status message as written by RocksDB into an Error.
+// Messages without a known code are returned with CodeUnknown.
+func ParseError(status string) *Error {
+	e := &Error{Code: CodeUnknown, Message: status, status: status}
+	for code, prefix := range errorCodePrefixes {
+		if !strings.HasPrefix(status, prefix+":") {
+			continue
+		}
+		e.Code = code
+		e.Message = strings.TrimPrefix(strings.TrimPrefix(status, prefix+":"), " ")
+		break
+	}
+	if e.Code == CodeUnknown {
+		return e
+	}
+	for subCode, msg := range errorSubCodeMessages {
+		if !strings.HasPrefix(e.Message, msg) {
+			continue
+		}
+		rest := e.Message[len(msg):]
+		if rest != "" && !strings.HasPrefix(rest, ": ") {
+			continue
+		}
+		e.SubCode = subCode
+		e.Message = strings.TrimPrefix(rest, ": ")
+		break
+	}
+	return e
+}
+
+// newError converts an error message returned by the C API into an error.
+func newError(status string) error {
+	return ParseError(status)
+}
@@ -0,0 +1,72 @@
+package gorocksdb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestParseError(t *testing.T) {
+	for _, c := range []struct {
+		status  string
+		code    ErrorCode
+		subCode ErrorSubCode
+		message string
+	}{
+		{"NotFound: ", CodeNotFound, SubCodeNone, ""},
+		{"Corruption: block checksum mismatch", CodeCorruption, SubCodeNone, "block checksum mismatch"},
+		{"Invalid argument: Column family not found: guide", CodeInvalidArgument, SubCodeNone, "Column family not found: guide"},
+		{"IO error: No space left on device: /tmp/db/000012.log", CodeIOError, SubCodeNoSpace, "/tmp/db/000012.log"},
+		{"IO error: While lock file: /tmp/db/LOCK", CodeIOError, SubCodeNone, "While lock file: /tmp/db/LOCK"},
+		{"Operation timed out: Timeout waiting to lock key", CodeTimedOut, SubCodeLockTimeout, ""},
+		{"Resource busy: ", CodeBusy, SubCodeNone, ""},
+		{"Resource busy: Deadlock", CodeBusy, SubCodeDeadlock, ""},
+		{"Operation failed. Try again.: Transaction could not check for conflicts", CodeTryAgain, SubCodeNone, "Transaction could not check for conflicts"},
+		{"Result incomplete: Write stall", CodeIncomplete, SubCodeNone, "Write stall"},
+		{"something unexpected", CodeUnknown, SubCodeNone, "something unexpected"},
+	} {
+		err := ParseError(c.status)
+		ensure.DeepEqual(t, err.Code, c.code, c.status)
+		ensure.DeepEqual(t, err.SubCode, c.subCode, c.status)
+		ensure.DeepEqual(t, err.Message, c.message, c.status)
+		ensure.DeepEqual(t, err.Error(), c.status)
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	err := newError("Operation timed out: Timeout waiting to lock key")
+	ensure.True(t, errors.Is(err, ErrTimedOut))
+	ensure.True(t, errors.Is(err, ErrLockTimeout))
+	ensure.False(t, errors.Is(err, ErrBusy))
+	ensure.False(t, errors.Is(err, ErrTransactionConflict))
+
+	err = newError("Resource busy: ")
+	ensure.True(t, errors.Is(err, ErrBusy))
+	ensure.False(t, errors.Is(err, ErrDeadlock))
+	ensure.True(t, errors.Is(err, ErrTransactionConflict))
+
+	err = newError("Resource busy: Deadlock")
+	ensure.True(t, errors.Is(err, ErrBusy))
+	ensure.True(t, errors.Is(err, ErrDeadlock))
+	ensure.False(t, errors.Is(err, ErrTransactionConflict))
+
+	err = newError("Resource busy: Failed to acquire lock due to max_num_locks limit")
+	ensure.False(t, errors.Is(err, ErrTransactionConflict))
+
+	err = newError("Operation failed. Try again.: ")
+	ensure.True(t, errors.Is(err, ErrTryAgain))
+	ensure.True(t, errors.Is(err, ErrTransactionConflict))
+
+	wrapped := fmt.Errorf("getting %q failed: %w", "key", newError("Corruption: bad block"))
+	ensure.True(t, errors.Is(wrapped, ErrCorruption))
+	var rocksErr *Error
+	ensure.True(t, errors.As(wrapped, &rocksErr))
+	ensure.DeepEqual(t, rocksErr.Message, "bad block")
+}
+
+func TestErrorSentinelMessage(t *testing.T) {
+	ensure.DeepEqual(t, ErrBusy.Error(), "Resource busy")
+	ensure.DeepEqual(t, ErrLockTimeout.Error(), "Operation timed out: Timeout waiting to lock key")
+}
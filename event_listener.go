@@ -0,0 +1,294 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+
+// FlushReason is the reason a memtable was flushed.
+type FlushReason int
+
+// Flush reasons.
+const (
+	FlushReasonOthers                = FlushReason(C.gorocksdb_flush_reason_others)
+	FlushReasonGetLiveFiles          = FlushReason(C.gorocksdb_flush_reason_get_live_files)
+	FlushReasonShutdown              = FlushReason(C.gorocksdb_flush_reason_shutdown)
+	FlushReasonExternalFileIngestion = FlushReason(C.gorocksdb_flush_reason_external_file_ingestion)
+	FlushReasonManualCompaction      = FlushReason(C.gorocksdb_flush_reason_manual_compaction)
+	FlushReasonWriteBufferManager    = FlushReason(C.gorocksdb_flush_reason_write_buffer_manager)
+	FlushReasonWriteBufferFull       = FlushReason(C.gorocksdb_flush_reason_write_buffer_full)
+	FlushReasonTest                  = FlushReason(C.gorocksdb_flush_reason_test)
+	FlushReasonDeleteFiles           = FlushReason(C.gorocksdb_flush_reason_delete_files)
+	FlushReasonAutoCompaction        = FlushReason(C.gorocksdb_flush_reason_auto_compaction)
+	FlushReasonManualFlush           = FlushReason(C.gorocksdb_flush_reason_manual_flush)
+	FlushReasonErrorRecovery         = FlushReason(C.gorocksdb_flush_reason_error_recovery)
+)
+
+// CompactionReason is the reason a compaction was started.
+type CompactionReason int
+
+// Compaction reasons.
+const (
+	CompactionReasonUnknown                    = CompactionReason(C.gorocksdb_compaction_reason_unknown)
+	CompactionReasonLevelL0FilesNum            = CompactionReason(C.gorocksdb_compaction_reason_level_l0_files_num)
+	CompactionReasonLevelMaxLevelSize          = CompactionReason(C.gorocksdb_compaction_reason_level_max_level_size)
+	CompactionReasonUniversalSizeAmplification = CompactionReason(C.gorocksdb_compaction_reason_universal_size_amplification)
+	CompactionReasonUniversalSizeRatio         = CompactionReason(C.gorocksdb_compaction_reason_universal_size_ratio)
+	CompactionReasonUniversalSortedRunNum      = CompactionReason(C.gorocksdb_compaction_reason_universal_sorted_run_num)
+	CompactionReasonFIFOMaxSize                = CompactionReason(C.gorocksdb_compaction_reason_fifo_max_size)
+	CompactionReasonFIFOReduceNumFiles         = CompactionReason(C.gorocksdb_compaction_reason_fifo_reduce_num_files)
+	CompactionReasonFIFOTTL                    = CompactionReason(C.gorocksdb_compaction_reason_fifo_ttl)
+	CompactionReasonManualCompaction           = CompactionReason(C.gorocksdb_compaction_reason_manual_compaction)
+	CompactionReasonFilesMarkedForCompaction   = CompactionReason(C.gorocksdb_compaction_reason_files_marked_for_compaction)
+	CompactionReasonBottommostFiles            = CompactionReason(C.gorocksdb_compaction_reason_bottommost_files)
+	CompactionReasonTTL                        = CompactionReason(C.gorocksdb_compaction_reason_ttl)
+	CompactionReasonFlush                      = CompactionReason(C.gorocksdb_compaction_reason_flush)
+	CompactionReasonExternalSstIngestion       = CompactionReason(C.gorocksdb_compaction_reason_external_sst_ingestion)
+	CompactionReasonPeriodicCompaction         = CompactionReason(C.gorocksdb_compaction_reason_periodic_compaction)
+)
+
+// WriteStallCondition is the write stall state of a column family.
+type WriteStallCondition int
+
+// Write stall conditions.
+const (
+	WriteStallConditionNormal  = WriteStallCondition(C.gorocksdb_write_stall_condition_normal)
+	WriteStallConditionDelayed = WriteStallCondition(C.gorocksdb_write_stall_condition_delayed)
+	WriteStallConditionStopped = WriteStallCondition(C.gorocksdb_write_stall_condition_stopped)
+)
+
+// BackgroundErrorReason is the operation which caused a background error.
+type BackgroundErrorReason int
+
+// Background error reasons.
+const (
+	BackgroundErrorReasonOther         = BackgroundErrorReason(C.gorocksdb_background_error_reason_other)
+	BackgroundErrorReasonFlush         = BackgroundErrorReason(C.gorocksdb_background_error_reason_flush)
+	BackgroundErrorReasonCompaction    = BackgroundErrorReason(C.gorocksdb_background_error_reason_compaction)
+	BackgroundErrorReasonWriteCallback = BackgroundErrorReason(C.gorocksdb_background_error_reason_write_callback)
+	BackgroundErrorReasonMemTable      = BackgroundErrorReason(C.gorocksdb_background_error_reason_memtable)
+)
+
+// TableFileCreationReason is the operation which created a table file.
+type TableFileCreationReason int
+
+// Table file creation reasons.
+const (
+	TableFileCreationReasonMisc       = TableFileCreationReason(C.gorocksdb_table_file_creation_reason_misc)
+	TableFileCreationReasonFlush      = TableFileCreationReason(C.gorocksdb_table_file_creation_reason_flush)
+	TableFileCreationReasonCompaction = TableFileCreationReason(C.gorocksdb_table_file_creation_reason_compaction)
+	TableFileCreationReasonRecovery   = TableFileCreationReason(C.gorocksdb_table_file_creation_reason_recovery)
+)
+
+// FlushJobInfo describes a completed flush.
+type FlushJobInfo struct {
+	ColumnFamilyName string
+	// FilePath is the path of the newly created table file.
+	FilePath string
+	ThreadID uint64
+	JobID    int
+	// TriggeredWritesSlowdown is true if the flush was started while writes
+	// were slowed down because of too many level 0 files.
+	TriggeredWritesSlowdown bool
+	// TriggeredWritesStop is true if the flush was started while writes
+	// were stopped because of too many level 0 files.
+	TriggeredWritesStop bool
+	SmallestSeqno       uint64
+	LargestSeqno        uint64
+	FlushReason         FlushReason
+}
+
+// CompactionJobInfo describes a completed compaction.
+type CompactionJobInfo struct {
+	ColumnFamilyName string
+	// Err is set if the compaction failed.
+	Err              error
+	ThreadID         uint64
+	JobID            int
+	BaseInputLevel   int
+	OutputLevel      int
+	InputFiles       []string
+	OutputFiles      []string
+	CompactionReason CompactionReason
+	ElapsedMicros    uint64
+	NumInputRecords  uint64
+	NumOutputRecords uint64
+	TotalInputBytes  uint64
+	TotalOutputBytes uint64
+}
+
+// WriteStallInfo describes a change of the write stall condition of a
+// column family.
+type WriteStallInfo struct {
+	ColumnFamilyName string
+	Condition        WriteStallCondition
+	PrevCondition    WriteStallCondition
+}
+
+// TableFileCreationInfo describes a created table file.
+type TableFileCreationInfo struct {
+	DBName           string
+	ColumnFamilyName string
+	FilePath         string
+	FileSize         uint64
+	JobID            int
+	Reason           TableFileCreationReason
+	// Err is set if the file could not be created.
+	Err error
+}
+
+// TableFileDeletionInfo describes a deleted table file.
+type TableFileDeletionInfo struct {
+	DBName   string
+	FilePath string
+	JobID    int
+	// Err is set if the file could not be deleted.
+	Err error
+}
+
+// An EventListener is notified by RocksDB about flushes, compactions, write
+// stalls, background errors and table files. Embed NoopEventListener to
+// implement only some of the callbacks.
+//
+// The callbacks are called from background threads of RocksDB, possibly
+// concurrently, and block the operation which triggered them. They must
+// return quickly and must not call back into the database, start longer work
+// like a backup in a new goroutine instead.
+type EventListener interface {
+	// OnFlushCompleted is called after a flush has finished.
+	OnFlushCompleted(info FlushJobInfo)
+
+	// OnCompactionCompleted is called after a compaction has finished,
+	// successfully or not.
+	OnCompactionCompleted(info CompactionJobInfo)
+
+	// OnStallConditionsChanged is called when the write stall condition of a
+	// column family changes.
+	OnStallConditionsChanged(info WriteStallInfo)
+
+	// OnBackgroundError is called when a background operation fails and
+	// puts the database into read-only mode.
+	OnBackgroundError(reason BackgroundErrorReason, err error)
+
+	// OnTableFileCreated is called after a table file has been created.
+	OnTableFileCreated(info TableFileCreationInfo)
+
+	// OnTableFileDeleted is called after a table file has been deleted.
+	OnTableFileDeleted(info TableFileDeletionInfo)
+}
+
+// NoopEventListener is an EventListener which ignores all events.
+type NoopEventListener struct{}
+
+// OnFlushCompleted implements EventListener.
+func (NoopEventListener) OnFlushCompleted(info FlushJobInfo) {}
+
+// OnCompactionCompleted implements EventListener.
+func (NoopEventListener) OnCompactionCompleted(info CompactionJobInfo) {}
+
+// OnStallConditionsChanged implements EventListener.
+func (NoopEventListener) OnStallConditionsChanged(info WriteStallInfo) {}
+
+// OnBackgroundError implements EventListener.
+func (NoopEventListener) OnBackgroundError(reason BackgroundErrorReason, err error) {}
+
+// OnTableFileCreated implements EventListener.
+func (NoopEventListener) OnTableFileCreated(info TableFileCreationInfo) {}
+
+// OnTableFileDeleted implements EventListener.
+func (NoopEventListener) OnTableFileDeleted(info TableFileDeletionInfo) {}
+
+// Hold references to event listeners.
+var eventListeners = NewCOWList()
+
+func registerEventListener(listener EventListener) int {
+	return eventListeners.Append(listener)
+}
+
+// statusToError converts a status message of an event into an error.
+func statusToError(cStatus *C.char) error {
+	if cStatus == nil {
+		return nil
+	}
+	return newError(C.GoString(cStatus))
+}
+
+// filePaths converts a C array of file paths into a []string.
+func filePaths(cPaths **C.char, cNum C.size_t) []string {
+	paths := make([]string, int(cNum))
+	for i, cPath := range charSlice(cPaths, C.int(cNum)) {
+		paths[i] = C.GoString(cPath)
+	}
+	return paths
+}
+
+//export gorocksdb_eventlistener_flush_completed
+func gorocksdb_eventlistener_flush_completed(idx int, cInfo *C.gorocksdb_flushjobinfo_t) {
+	eventListeners.Get(idx).(EventListener).OnFlushCompleted(FlushJobInfo{
+		ColumnFamilyName:        C.GoString(cInfo.cf_name),
+		FilePath:                C.GoString(cInfo.file_path),
+		ThreadID:                uint64(cInfo.thread_id),
+		JobID:                   int(cInfo.job_id),
+		TriggeredWritesSlowdown: cInfo.triggered_writes_slowdown != 0,
+		TriggeredWritesStop:     cInfo.triggered_writes_stop != 0,
+		SmallestSeqno:           uint64(cInfo.smallest_seqno),
+		LargestSeqno:            uint64(cInfo.largest_seqno),
+		FlushReason:             FlushReason(cInfo.flush_reason),
+	})
+}
+
+//export gorocksdb_eventlistener_compaction_completed
+func gorocksdb_eventlistener_compaction_completed(idx int, cInfo *C.gorocksdb_compactionjobinfo_t) {
+	eventListeners.Get(idx).(EventListener).OnCompactionCompleted(CompactionJobInfo{
+		ColumnFamilyName: C.GoString(cInfo.cf_name),
+		Err:              statusToError(cInfo.status),
+		ThreadID:         uint64(cInfo.thread_id),
+		JobID:            int(cInfo.job_id),
+		BaseInputLevel:   int(cInfo.base_input_level),
+		OutputLevel:      int(cInfo.output_level),
+		InputFiles:       filePaths(cInfo.input_files, cInfo.num_input_files),
+		OutputFiles:      filePaths(cInfo.output_files, cInfo.num_output_files),
+		CompactionReason: CompactionReason(cInfo.compaction_reason),
+		ElapsedMicros:    uint64(cInfo.elapsed_micros),
+		NumInputRecords:  uint64(cInfo.num_input_records),
+		NumOutputRecords: uint64(cInfo.num_output_records),
+		TotalInputBytes:  uint64(cInfo.total_input_bytes),
+		TotalOutputBytes: uint64(cInfo.total_output_bytes),
+	})
+}
+
+//export gorocksdb_eventlistener_stall_conditions_changed
+func gorocksdb_eventlistener_stall_conditions_changed(idx int, cInfo *C.gorocksdb_writestallinfo_t) {
+	eventListeners.Get(idx).(EventListener).OnStallConditionsChanged(WriteStallInfo{
+		ColumnFamilyName: C.GoString(cInfo.cf_name),
+		Condition:        WriteStallCondition(cInfo.cur),
+		PrevCondition:    WriteStallCondition(cInfo.prev),
+	})
+}
+
+//export gorocksdb_eventlistener_background_error
+func gorocksdb_eventlistener_background_error(idx int, cReason C.int, cStatus *C.char) {
+	eventListeners.Get(idx).(EventListener).OnBackgroundError(BackgroundErrorReason(cReason), statusToError(cStatus))
+}
+
+//export gorocksdb_eventlistener_table_file_created
+func gorocksdb_eventlistener_table_file_created(idx int, cInfo *C.gorocksdb_tablefilecreationinfo_t) {
+	eventListeners.Get(idx).(EventListener).OnTableFileCreated(TableFileCreationInfo{
+		DBName:           C.GoString(cInfo.db_name),
+		ColumnFamilyName: C.GoString(cInfo.cf_name),
+		FilePath:         C.GoString(cInfo.file_path),
+		FileSize:         uint64(cInfo.file_size),
+		JobID:            int(cInfo.job_id),
+		Reason:           TableFileCreationReason(cInfo.reason),
+		Err:              statusToError(cInfo.status),
+	})
+}
+
+//export gorocksdb_eventlistener_table_file_deleted
+func gorocksdb_eventlistener_table_file_deleted(idx int, cInfo *C.gorocksdb_tablefiledeletioninfo_t) {
+	eventListeners.Get(idx).(EventListener).OnTableFileDeleted(TableFileDeletionInfo{
+		DBName:   C.GoString(cInfo.db_name),
+		FilePath: C.GoString(cInfo.file_path),
+		JobID:    int(cInfo.job_id),
+		Err:      statusToError(cInfo.status),
+	})
+}
@@ -0,0 +1,78 @@
+package gorocksdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestEventListener(t *testing.T) {
+	listener := &mockEventListener{
+		flushes:      make(chan FlushJobInfo, 10),
+		compactions:  make(chan CompactionJobInfo, 10),
+		createdFiles: make(chan TableFileCreationInfo, 10),
+	}
+	db := newTestDB(t, "TestEventListener", func(opts *Options) {
+		opts.AddEventListener(listener)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	for _, key := range []string{"a", "b"} {
+		ensure.Nil(t, db.Put(wo, []byte(key), []byte("value")))
+		ensure.Nil(t, db.Flush(fo))
+
+		select {
+		case info := <-listener.flushes:
+			ensure.DeepEqual(t, info.ColumnFamilyName, "default")
+			ensure.DeepEqual(t, info.FlushReason, FlushReasonManualFlush)
+			ensure.True(t, info.FilePath != "")
+		case <-time.After(10 * time.Second):
+			t.Fatal("flush not reported")
+		}
+		select {
+		case info := <-listener.createdFiles:
+			ensure.DeepEqual(t, info.ColumnFamilyName, "default")
+			ensure.DeepEqual(t, info.Reason, TableFileCreationReasonFlush)
+			ensure.Nil(t, info.Err)
+			ensure.True(t, info.FileSize > 0)
+		case <-time.After(10 * time.Second):
+			t.Fatal("table file creation not reported")
+		}
+	}
+
+	db.CompactRange(Range{})
+	select {
+	case info := <-listener.compactions:
+		ensure.DeepEqual(t, info.ColumnFamilyName, "default")
+		ensure.Nil(t, info.Err)
+		ensure.DeepEqual(t, info.CompactionReason, CompactionReasonManualCompaction)
+		ensure.DeepEqual(t, len(info.InputFiles), 2)
+		ensure.DeepEqual(t, info.NumInputRecords, uint64(2))
+	case <-time.After(10 * time.Second):
+		t.Fatal("compaction not reported")
+	}
+}
+
+type mockEventListener struct {
+	NoopEventListener
+	flushes      chan FlushJobInfo
+	compactions  chan CompactionJobInfo
+	createdFiles chan TableFileCreationInfo
+}
+
+func (l *mockEventListener) OnFlushCompleted(info FlushJobInfo) {
+	l.flushes <- info
+}
+
+func (l *mockEventListener) OnCompactionCompleted(info CompactionJobInfo) {
+	l.compactions <- info
+}
+
+func (l *mockEventListener) OnTableFileCreated(info TableFileCreationInfo) {
+	l.createdFiles <- info
+}
@@ -1,6 +1,13 @@
+#ifndef GOROCKSDB_H
+#define GOROCKSDB_H
+
 #include <stdlib.h>
 #include "rocksdb/c.h"
 
+#ifdef __cplusplus
+extern "C" {
+#endif
+
 // This API provides convenient C wrapper functions for rocksdb client.
 
 /* Base */
@@ -28,3 +35,378 @@ extern void gorocksdb_mergeoperator_delete_value(void* state, const char* v, siz
 /* Slice Transform */
 
 extern rocksdb_slicetransform_t* gorocksdb_slicetransform_create(uintptr_t idx);
+
+/* C++ extensions, implemented in gorocksdb_ext.cc */
+
+extern unsigned char gorocksdb_property_map(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, const char* propname,
+    char*** keys, char*** values, size_t* size);
+
+/* Comparator */
+
+extern int gorocksdb_column_family_compare(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, const char* a, size_t a_len, const char* b,
+    size_t b_len);
+
+/* Backup */
+
+typedef struct gorocksdb_backup_engine_options_t gorocksdb_backup_engine_options_t;
+
+extern gorocksdb_backup_engine_options_t* gorocksdb_backup_engine_options_create(const char* backup_dir);
+extern void gorocksdb_backup_engine_options_destroy(gorocksdb_backup_engine_options_t* opts);
+extern const char* gorocksdb_backup_engine_options_get_backup_dir(const gorocksdb_backup_engine_options_t* opts);
+extern void gorocksdb_backup_engine_options_set_share_table_files(gorocksdb_backup_engine_options_t* opts, unsigned char v);
+extern void gorocksdb_backup_engine_options_set_share_files_with_checksum(gorocksdb_backup_engine_options_t* opts, unsigned char v);
+extern void gorocksdb_backup_engine_options_set_sync(gorocksdb_backup_engine_options_t* opts, unsigned char v);
+extern void gorocksdb_backup_engine_options_set_destroy_old_data(gorocksdb_backup_engine_options_t* opts, unsigned char v);
+extern void gorocksdb_backup_engine_options_set_backup_log_files(gorocksdb_backup_engine_options_t* opts, unsigned char v);
+extern void gorocksdb_backup_engine_options_set_backup_rate_limit(gorocksdb_backup_engine_options_t* opts, uint64_t v);
+extern void gorocksdb_backup_engine_options_set_backup_rate_limiter(gorocksdb_backup_engine_options_t* opts, rocksdb_ratelimiter_t* limiter);
+extern void gorocksdb_backup_engine_options_set_restore_rate_limit(gorocksdb_backup_engine_options_t* opts, uint64_t v);
+extern void gorocksdb_backup_engine_options_set_restore_rate_limiter(gorocksdb_backup_engine_options_t* opts, rocksdb_ratelimiter_t* limiter);
+extern void gorocksdb_backup_engine_options_set_max_background_operations(gorocksdb_backup_engine_options_t* opts, int v);
+extern void gorocksdb_backup_engine_options_set_callback_trigger_interval_size(gorocksdb_backup_engine_options_t* opts, uint64_t v);
+extern void gorocksdb_backup_engine_options_set_max_valid_backups_to_open(gorocksdb_backup_engine_options_t* opts, int v);
+extern rocksdb_backup_engine_t* gorocksdb_backup_engine_open_opts(
+    const rocksdb_options_t* db_options, const gorocksdb_backup_engine_options_t* opts, char** errptr);
+extern void gorocksdb_backup_engine_create_new_backup_with_metadata(
+    rocksdb_backup_engine_t* be, rocksdb_t* db, const char* app_metadata, size_t app_metadata_len,
+    unsigned char flush_before_backup, char** errptr);
+extern void gorocksdb_backup_engine_delete_backup(rocksdb_backup_engine_t* be, uint32_t backup_id, char** errptr);
+extern const char* gorocksdb_backup_engine_info_app_metadata(
+    const rocksdb_backup_engine_info_t* info, int index, size_t* len);
+
+/* Export and import of column families */
+
+typedef struct gorocksdb_export_import_files_metadata_t gorocksdb_export_import_files_metadata_t;
+
+extern gorocksdb_export_import_files_metadata_t* gorocksdb_checkpoint_export_column_family(
+    rocksdb_checkpoint_t* checkpoint, rocksdb_column_family_handle_t* column_family, const char* export_dir,
+    char** errptr);
+extern rocksdb_column_family_handle_t* gorocksdb_create_column_family_with_import(
+    rocksdb_t* db, const rocksdb_options_t* column_family_options, const char* column_family_name,
+    const gorocksdb_export_import_files_metadata_t* metadata, char** errptr);
+extern gorocksdb_export_import_files_metadata_t* gorocksdb_export_import_files_metadata_create(
+    const char* db_comparator_name);
+extern void gorocksdb_export_import_files_metadata_destroy(gorocksdb_export_import_files_metadata_t* metadata);
+extern const char* gorocksdb_export_import_files_metadata_db_comparator_name(
+    const gorocksdb_export_import_files_metadata_t* metadata);
+extern int gorocksdb_export_import_files_metadata_count(const gorocksdb_export_import_files_metadata_t* metadata);
+extern void gorocksdb_export_import_files_metadata_add_file(
+    gorocksdb_export_import_files_metadata_t* metadata, const char* name, const char* db_path,
+    const char* column_family_name, int level, uint64_t size,
+    const char* smallest_key, size_t smallest_key_len, const char* largest_key, size_t largest_key_len,
+    uint64_t smallest_seqno, uint64_t largest_seqno, uint64_t num_entries, uint64_t num_deletions);
+extern const char* gorocksdb_export_import_files_metadata_file_name(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index);
+extern const char* gorocksdb_export_import_files_metadata_file_db_path(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index);
+extern const char* gorocksdb_export_import_files_metadata_file_column_family_name(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index);
+extern int gorocksdb_export_import_files_metadata_file_level(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index);
+extern uint64_t gorocksdb_export_import_files_metadata_file_size(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index);
+extern const char* gorocksdb_export_import_files_metadata_file_smallest_key(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index, size_t* len);
+extern const char* gorocksdb_export_import_files_metadata_file_largest_key(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index, size_t* len);
+extern uint64_t gorocksdb_export_import_files_metadata_file_smallest_seqno(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index);
+extern uint64_t gorocksdb_export_import_files_metadata_file_largest_seqno(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index);
+extern uint64_t gorocksdb_export_import_files_metadata_file_num_entries(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index);
+extern uint64_t gorocksdb_export_import_files_metadata_file_num_deletions(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index);
+
+/* External file ingestion */
+
+extern void gorocksdb_ingest_external_files(
+    rocksdb_t* db, int num_args, rocksdb_column_family_handle_t* const* column_families,
+    const rocksdb_ingestexternalfileoptions_t* const* options, const size_t* num_files,
+    const char* const* file_paths, char** errptr);
+extern void gorocksdb_ingestexternalfileoptions_set_fail_if_not_bottommost_level(
+    rocksdb_ingestexternalfileoptions_t* opts, unsigned char v);
+extern void gorocksdb_ingestexternalfileoptions_set_verify_checksums_before_ingest(
+    rocksdb_ingestexternalfileoptions_t* opts, unsigned char v);
+extern void gorocksdb_ingestexternalfileoptions_set_write_global_seqno(
+    rocksdb_ingestexternalfileoptions_t* opts, unsigned char v);
+
+/* File metadata */
+
+extern const char* gorocksdb_livefiles_column_family_name(const rocksdb_livefiles_t* lf, int index);
+extern const char* gorocksdb_livefiles_db_path(const rocksdb_livefiles_t* lf, int index);
+extern uint64_t gorocksdb_livefiles_smallest_seqno(const rocksdb_livefiles_t* lf, int index);
+extern uint64_t gorocksdb_livefiles_largest_seqno(const rocksdb_livefiles_t* lf, int index);
+extern unsigned char gorocksdb_livefiles_being_compacted(const rocksdb_livefiles_t* lf, int index);
+
+typedef struct gorocksdb_column_family_metadata_t gorocksdb_column_family_metadata_t;
+
+typedef struct {
+    const char* name;
+    const char* db_path;
+    uint64_t size;
+    const char* smallest_key;
+    size_t smallest_key_len;
+    const char* largest_key;
+    size_t largest_key_len;
+    uint64_t smallest_seqno;
+    uint64_t largest_seqno;
+    unsigned char being_compacted;
+    uint64_t num_entries;
+    uint64_t num_deletions;
+} gorocksdb_sst_file_metadata_t;
+
+extern gorocksdb_column_family_metadata_t* gorocksdb_get_column_family_metadata(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family);
+extern const char* gorocksdb_column_family_metadata_name(const gorocksdb_column_family_metadata_t* cf_meta);
+extern uint64_t gorocksdb_column_family_metadata_size(const gorocksdb_column_family_metadata_t* cf_meta);
+extern size_t gorocksdb_column_family_metadata_file_count(const gorocksdb_column_family_metadata_t* cf_meta);
+extern int gorocksdb_column_family_metadata_level_count(const gorocksdb_column_family_metadata_t* cf_meta);
+extern int gorocksdb_column_family_metadata_level(const gorocksdb_column_family_metadata_t* cf_meta, int level_index);
+extern uint64_t gorocksdb_column_family_metadata_level_size(
+    const gorocksdb_column_family_metadata_t* cf_meta, int level_index);
+extern int gorocksdb_column_family_metadata_level_file_count(
+    const gorocksdb_column_family_metadata_t* cf_meta, int level_index);
+extern void gorocksdb_column_family_metadata_level_file(
+    const gorocksdb_column_family_metadata_t* cf_meta, int level_index, int file_index,
+    gorocksdb_sst_file_metadata_t* file);
+extern void gorocksdb_column_family_metadata_destroy(gorocksdb_column_family_metadata_t* cf_meta);
+
+/* Compaction */
+
+enum {
+    gorocksdb_bottommost_level_compaction_skip = 0,
+    gorocksdb_bottommost_level_compaction_if_have_compaction_filter = 1,
+    gorocksdb_bottommost_level_compaction_force = 2,
+    gorocksdb_bottommost_level_compaction_force_optimized = 3
+};
+
+extern void gorocksdb_compactoptions_set_target_path_id(rocksdb_compactoptions_t* opts, uint32_t v);
+extern void gorocksdb_compact_files(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, const char* const* file_names,
+    size_t num_files, int output_level, char** errptr);
+
+/* Deletion of files */
+
+extern void gorocksdb_delete_files_in_ranges(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, int num_ranges,
+    const char* const* range_start_key, const size_t* range_start_key_len,
+    const char* const* range_limit_key, const size_t* range_limit_key_len,
+    unsigned char include_end, char** errptr);
+
+/* Table properties */
+
+typedef struct gorocksdb_table_properties_t gorocksdb_table_properties_t;
+
+typedef struct {
+    uint64_t data_size;
+    uint64_t index_size;
+    uint64_t filter_size;
+    uint64_t raw_key_size;
+    uint64_t raw_value_size;
+    uint64_t num_data_blocks;
+    uint64_t num_entries;
+    uint64_t num_deletions;
+    uint64_t num_merge_operands;
+    uint64_t num_range_deletions;
+    uint64_t format_version;
+    uint64_t creation_time;
+    uint64_t oldest_key_time;
+    uint64_t file_creation_time;
+    const char* column_family_name;
+    const char* comparator_name;
+    const char* merge_operator_name;
+    const char* prefix_extractor_name;
+    const char* compression_name;
+    size_t num_user_collected_properties;
+} gorocksdb_table_properties_data_t;
+
+extern void gorocksdb_table_properties_get_data(
+    const gorocksdb_table_properties_t* props, gorocksdb_table_properties_data_t* data);
+extern void gorocksdb_table_properties_get_user_collected(
+    const gorocksdb_table_properties_t* props, const char** keys, size_t* key_lens,
+    const char** values, size_t* value_lens);
+extern void gorocksdb_table_properties_destroy(gorocksdb_table_properties_t* props);
+
+typedef struct gorocksdb_table_properties_collection_t gorocksdb_table_properties_collection_t;
+
+extern gorocksdb_table_properties_collection_t* gorocksdb_get_properties_of_all_tables(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, char** errptr);
+extern gorocksdb_table_properties_collection_t* gorocksdb_get_properties_of_tables_in_range(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, int num_ranges,
+    const char* const* range_start_key, const size_t* range_start_key_len,
+    const char* const* range_limit_key, const size_t* range_limit_key_len, char** errptr);
+extern int gorocksdb_table_properties_collection_count(const gorocksdb_table_properties_collection_t* collection);
+extern const char* gorocksdb_table_properties_collection_name(
+    const gorocksdb_table_properties_collection_t* collection, int index);
+extern gorocksdb_table_properties_t* gorocksdb_table_properties_collection_properties(
+    const gorocksdb_table_properties_collection_t* collection, int index);
+extern void gorocksdb_table_properties_collection_destroy(gorocksdb_table_properties_collection_t* collection);
+
+/* Table Properties Collector */
+
+enum {
+    gorocksdb_entry_put = 0,
+    gorocksdb_entry_delete = 1,
+    gorocksdb_entry_single_delete = 2,
+    gorocksdb_entry_merge = 3,
+    gorocksdb_entry_range_deletion = 4,
+    gorocksdb_entry_blob_index = 5,
+    gorocksdb_entry_other = 6
+};
+
+typedef struct gorocksdb_user_collected_properties_t gorocksdb_user_collected_properties_t;
+
+extern void gorocksdb_options_add_table_properties_collector_factory(rocksdb_options_t* opts, uintptr_t idx);
+extern void gorocksdb_user_collected_properties_add(
+    gorocksdb_user_collected_properties_t* props, const char* key, size_t key_len,
+    const char* value, size_t value_len);
+
+/* SST File Reader */
+
+typedef struct gorocksdb_sstfilereader_t gorocksdb_sstfilereader_t;
+
+extern gorocksdb_sstfilereader_t* gorocksdb_sstfilereader_create(const rocksdb_options_t* options);
+extern void gorocksdb_sstfilereader_open(gorocksdb_sstfilereader_t* reader, const char* file_path, char** errptr);
+extern rocksdb_iterator_t* gorocksdb_sstfilereader_new_iterator(
+    gorocksdb_sstfilereader_t* reader, const rocksdb_readoptions_t* options);
+extern gorocksdb_table_properties_t* gorocksdb_sstfilereader_get_table_properties(gorocksdb_sstfilereader_t* reader);
+extern void gorocksdb_sstfilereader_verify_checksum(gorocksdb_sstfilereader_t* reader, char** errptr);
+extern void gorocksdb_sstfilereader_destroy(gorocksdb_sstfilereader_t* reader);
+
+/* TransactionDB */
+
+extern rocksdb_t* gorocksdb_transactiondb_get_base_db(rocksdb_transactiondb_t* txn_db);
+extern void gorocksdb_transactiondb_close_base_db(rocksdb_t* base_db);
+
+/* Transaction */
+
+extern void gorocksdb_transaction_singledelete(
+    rocksdb_transaction_t* txn, rocksdb_column_family_handle_t* column_family, const char* key, size_t klen,
+    char** errptr);
+
+/* Event Listener */
+
+enum {
+    gorocksdb_flush_reason_others = 0,
+    gorocksdb_flush_reason_get_live_files = 1,
+    gorocksdb_flush_reason_shutdown = 2,
+    gorocksdb_flush_reason_external_file_ingestion = 3,
+    gorocksdb_flush_reason_manual_compaction = 4,
+    gorocksdb_flush_reason_write_buffer_manager = 5,
+    gorocksdb_flush_reason_write_buffer_full = 6,
+    gorocksdb_flush_reason_test = 7,
+    gorocksdb_flush_reason_delete_files = 8,
+    gorocksdb_flush_reason_auto_compaction = 9,
+    gorocksdb_flush_reason_manual_flush = 10,
+    gorocksdb_flush_reason_error_recovery = 11
+};
+
+enum {
+    gorocksdb_compaction_reason_unknown = 0,
+    gorocksdb_compaction_reason_level_l0_files_num = 1,
+    gorocksdb_compaction_reason_level_max_level_size = 2,
+    gorocksdb_compaction_reason_universal_size_amplification = 3,
+    gorocksdb_compaction_reason_universal_size_ratio = 4,
+    gorocksdb_compaction_reason_universal_sorted_run_num = 5,
+    gorocksdb_compaction_reason_fifo_max_size = 6,
+    gorocksdb_compaction_reason_fifo_reduce_num_files = 7,
+    gorocksdb_compaction_reason_fifo_ttl = 8,
+    gorocksdb_compaction_reason_manual_compaction = 9,
+    gorocksdb_compaction_reason_files_marked_for_compaction = 10,
+    gorocksdb_compaction_reason_bottommost_files = 11,
+    gorocksdb_compaction_reason_ttl = 12,
+    gorocksdb_compaction_reason_flush = 13,
+    gorocksdb_compaction_reason_external_sst_ingestion = 14,
+    gorocksdb_compaction_reason_periodic_compaction = 15
+};
+
+enum {
+    gorocksdb_write_stall_condition_normal = 0,
+    gorocksdb_write_stall_condition_delayed = 1,
+    gorocksdb_write_stall_condition_stopped = 2
+};
+
+enum {
+    gorocksdb_background_error_reason_other = 0,
+    gorocksdb_background_error_reason_flush = 1,
+    gorocksdb_background_error_reason_compaction = 2,
+    gorocksdb_background_error_reason_write_callback = 3,
+    gorocksdb_background_error_reason_memtable = 4
+};
+
+enum {
+    gorocksdb_table_file_creation_reason_misc = 0,
+    gorocksdb_table_file_creation_reason_flush = 1,
+    gorocksdb_table_file_creation_reason_compaction = 2,
+    gorocksdb_table_file_creation_reason_recovery = 3
+};
+
+typedef struct {
+    const char* cf_name;
+    const char* file_path;
+    uint64_t thread_id;
+    int job_id;
+    unsigned char triggered_writes_slowdown;
+    unsigned char triggered_writes_stop;
+    uint64_t smallest_seqno;
+    uint64_t largest_seqno;
+    int flush_reason;
+} gorocksdb_flushjobinfo_t;
+
+typedef struct {
+    const char* cf_name;
+    const char* status;
+    uint64_t thread_id;
+    int job_id;
+    int base_input_level;
+    int output_level;
+    const char** input_files;
+    size_t num_input_files;
+    const char** output_files;
+    size_t num_output_files;
+    int compaction_reason;
+    uint64_t elapsed_micros;
+    uint64_t num_input_records;
+    uint64_t num_output_records;
+    uint64_t total_input_bytes;
+    uint64_t total_output_bytes;
+} gorocksdb_compactionjobinfo_t;
+
+typedef struct {
+    const char* cf_name;
+    int cur;
+    int prev;
+} gorocksdb_writestallinfo_t;
+
+typedef struct {
+    const char* db_name;
+    const char* cf_name;
+    const char* file_path;
+    uint64_t file_size;
+    int job_id;
+    int reason;
+    const char* status;
+} gorocksdb_tablefilecreationinfo_t;
+
+typedef struct {
+    const char* db_name;
+    const char* file_path;
+    int job_id;
+    const char* status;
+} gorocksdb_tablefiledeletioninfo_t;
+
+extern void gorocksdb_options_add_eventlistener(rocksdb_options_t* opts, uintptr_t idx);
+
+/* Logger */
+
+extern void gorocksdb_options_set_info_logger(rocksdb_options_t* opts, uintptr_t idx);
+extern void gorocksdb_options_sync_info_logger_level(rocksdb_options_t* opts);
+
+#ifdef __cplusplus
+}  /* end extern "C" */
+#endif
+
+#endif  /* GOROCKSDB_H */
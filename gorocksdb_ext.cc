@@ -0,0 +1,981 @@
+// Wrapper functions for RocksDB features which are not available through
+// the RocksDB C API. Features the C API provides are used from Go directly.
+
+#include <cstdarg>
+#include <cstdio>
+#include <cstring>
+#include <map>
+#include <memory>
+#include <string>
+#include <vector>
+
+#include "rocksdb/convenience.h"
+#include "rocksdb/db.h"
+#include "rocksdb/env.h"
+#include "rocksdb/listener.h"
+#include "rocksdb/metadata.h"
+#include "rocksdb/options.h"
+#include "rocksdb/rate_limiter.h"
+#include "rocksdb/sst_file_reader.h"
+#include "rocksdb/table_properties.h"
+#include "rocksdb/utilities/checkpoint.h"
+#include "rocksdb/utilities/transaction_db.h"
+#include "rocksdb/version.h"
+#if __has_include("rocksdb/utilities/backup_engine.h")
+#include "rocksdb/utilities/backup_engine.h"
+using rocksdb::BackupEngineOptions;
+#else
+#include "rocksdb/utilities/backupable_db.h"
+using BackupEngineOptions = rocksdb::BackupableDBOptions;
+#endif
+
+#include "gorocksdb.h"
+#include "_cgo_export.h"
+
+using rocksdb::BackgroundErrorReason;
+using rocksdb::BackupEngine;
+using rocksdb::BackupInfo;
+using rocksdb::Checkpoint;
+using rocksdb::ColumnFamilyHandle;
+using rocksdb::CompactRangeOptions;
+using rocksdb::CompactionJobInfo;
+using rocksdb::CompactionOptions;
+using rocksdb::CompactionReason;
+using rocksdb::DB;
+using rocksdb::ExportImportFilesMetaData;
+using rocksdb::FlushJobInfo;
+using rocksdb::FlushReason;
+using rocksdb::ImportColumnFamilyOptions;
+using rocksdb::InfoLogLevel;
+using rocksdb::IngestExternalFileArg;
+using rocksdb::IngestExternalFileOptions;
+using rocksdb::LiveFileMetaData;
+using rocksdb::Iterator;
+using rocksdb::Options;
+using rocksdb::RateLimiter;
+using rocksdb::ReadOptions;
+using rocksdb::Slice;
+using rocksdb::SstFileReader;
+using rocksdb::Status;
+using rocksdb::TableFileCreationInfo;
+using rocksdb::TableFileCreationReason;
+using rocksdb::TableFileDeletionInfo;
+using rocksdb::TableProperties;
+using rocksdb::Transaction;
+using rocksdb::TransactionDB;
+using rocksdb::WriteStallCondition;
+using rocksdb::WriteStallInfo;
+
+// The structs below mirror the definitions of rocksdb/c.cc, which are not
+// exported, to reach the C++ objects behind the C API handles. Only the
+// handles the wrappers need are mirrored, and only up to the members they
+// use. Objects of the mirrored structs are only allocated here for the
+// single member structs, which have the same definition in all supported
+// versions. The mirrors must be checked against rocksdb/c.cc before the
+// supported version range is extended.
+#if ROCKSDB_MAJOR < 6 || (ROCKSDB_MAJOR == 6 && ROCKSDB_MINOR < 12) || ROCKSDB_MAJOR > 7
+#error "gorocksdb_ext.cc mirrors rocksdb/c.cc of RocksDB 6.12 to 7.x, check the mirrors for this version"
+#endif
+
+struct rocksdb_t { DB* rep; };
+struct rocksdb_column_family_handle_t { ColumnFamilyHandle* rep; };
+struct rocksdb_options_t { Options rep; };
+struct rocksdb_backup_engine_t { BackupEngine* rep; };
+struct rocksdb_backup_engine_info_t { std::vector<BackupInfo> rep; };
+struct rocksdb_transactiondb_t { TransactionDB* rep; };
+struct rocksdb_transaction_t { Transaction* rep; };
+struct rocksdb_ratelimiter_t { std::shared_ptr<RateLimiter> rep; };
+struct rocksdb_checkpoint_t { Checkpoint* rep; };
+struct rocksdb_compactoptions_t { CompactRangeOptions rep; };
+struct rocksdb_ingestexternalfileoptions_t { IngestExternalFileOptions rep; };
+struct rocksdb_iterator_t { Iterator* rep; };
+struct rocksdb_livefiles_t { std::vector<LiveFileMetaData> rep; };
+struct rocksdb_readoptions_t { ReadOptions rep; };  // followed by the iterate bounds in c.cc
+
+struct gorocksdb_backup_engine_options_t { BackupEngineOptions rep; };
+struct gorocksdb_export_import_files_metadata_t { ExportImportFilesMetaData rep; };
+struct gorocksdb_table_properties_t { std::shared_ptr<const TableProperties> rep; };
+struct gorocksdb_table_properties_collection_t {
+    std::vector<std::pair<std::string, std::shared_ptr<const TableProperties>>> rep;
+};
+struct gorocksdb_user_collected_properties_t { rocksdb::UserCollectedProperties* rep; };
+struct gorocksdb_column_family_metadata_t { rocksdb::ColumnFamilyMetaData rep; };
+struct gorocksdb_sstfilereader_t { SstFileReader* rep; };
+
+static bool gorocksdb_save_error(char** errptr, const Status& s) {
+    if (s.ok()) {
+        return false;
+    }
+    if (*errptr != nullptr) {
+        free(*errptr);
+    }
+    *errptr = strdup(s.ToString().c_str());
+    return true;
+}
+
+static char* gorocksdb_copy_string(const std::string& str) {
+    char* result = static_cast<char*>(malloc(str.size() + 1));
+    memcpy(result, str.data(), str.size());
+    result[str.size()] = '\0';
+    return result;
+}
+
+/* Properties */
+
+unsigned char gorocksdb_property_map(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, const char* propname,
+    char*** keys, char*** values, size_t* size) {
+    ColumnFamilyHandle* cf = column_family != nullptr ? column_family->rep : db->rep->DefaultColumnFamily();
+    std::map<std::string, std::string> props;
+    if (!db->rep->GetMapProperty(cf, Slice(propname), &props)) {
+        return 0;
+    }
+    *size = props.size();
+    *keys = static_cast<char**>(malloc(sizeof(char*) * props.size()));
+    *values = static_cast<char**>(malloc(sizeof(char*) * props.size()));
+    size_t i = 0;
+    for (const auto& prop : props) {
+        (*keys)[i] = gorocksdb_copy_string(prop.first);
+        (*values)[i] = gorocksdb_copy_string(prop.second);
+        i++;
+    }
+    return 1;
+}
+
+/* Comparator */
+
+int gorocksdb_column_family_compare(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, const char* a, size_t a_len, const char* b,
+    size_t b_len) {
+    ColumnFamilyHandle* cf = column_family != nullptr ? column_family->rep : db->rep->DefaultColumnFamily();
+    return cf->GetComparator()->Compare(Slice(a, a_len), Slice(b, b_len));
+}
+
+/* Backup */
+
+gorocksdb_backup_engine_options_t* gorocksdb_backup_engine_options_create(const char* backup_dir) {
+    return new gorocksdb_backup_engine_options_t{BackupEngineOptions(backup_dir)};
+}
+
+void gorocksdb_backup_engine_options_destroy(gorocksdb_backup_engine_options_t* opts) {
+    delete opts;
+}
+
+const char* gorocksdb_backup_engine_options_get_backup_dir(const gorocksdb_backup_engine_options_t* opts) {
+    return opts->rep.backup_dir.c_str();
+}
+
+void gorocksdb_backup_engine_options_set_share_table_files(gorocksdb_backup_engine_options_t* opts, unsigned char v) {
+    opts->rep.share_table_files = v;
+}
+
+void gorocksdb_backup_engine_options_set_share_files_with_checksum(gorocksdb_backup_engine_options_t* opts, unsigned char v) {
+    opts->rep.share_files_with_checksum = v;
+}
+
+void gorocksdb_backup_engine_options_set_sync(gorocksdb_backup_engine_options_t* opts, unsigned char v) {
+    opts->rep.sync = v;
+}
+
+void gorocksdb_backup_engine_options_set_destroy_old_data(gorocksdb_backup_engine_options_t* opts, unsigned char v) {
+    opts->rep.destroy_old_data = v;
+}
+
+void gorocksdb_backup_engine_options_set_backup_log_files(gorocksdb_backup_engine_options_t* opts, unsigned char v) {
+    opts->rep.backup_log_files = v;
+}
+
+void gorocksdb_backup_engine_options_set_backup_rate_limit(gorocksdb_backup_engine_options_t* opts, uint64_t v) {
+    opts->rep.backup_rate_limit = v;
+}
+
+void gorocksdb_backup_engine_options_set_backup_rate_limiter(
+    gorocksdb_backup_engine_options_t* opts, rocksdb_ratelimiter_t* limiter) {
+    opts->rep.backup_rate_limiter = limiter->rep;
+}
+
+void gorocksdb_backup_engine_options_set_restore_rate_limit(gorocksdb_backup_engine_options_t* opts, uint64_t v) {
+    opts->rep.restore_rate_limit = v;
+}
+
+void gorocksdb_backup_engine_options_set_restore_rate_limiter(
+    gorocksdb_backup_engine_options_t* opts, rocksdb_ratelimiter_t* limiter) {
+    opts->rep.restore_rate_limiter = limiter->rep;
+}
+
+void gorocksdb_backup_engine_options_set_max_background_operations(gorocksdb_backup_engine_options_t* opts, int v) {
+    opts->rep.max_background_operations = v;
+}
+
+void gorocksdb_backup_engine_options_set_callback_trigger_interval_size(
+    gorocksdb_backup_engine_options_t* opts, uint64_t v) {
+    opts->rep.callback_trigger_interval_size = v;
+}
+
+void gorocksdb_backup_engine_options_set_max_valid_backups_to_open(gorocksdb_backup_engine_options_t* opts, int v) {
+    opts->rep.max_valid_backups_to_open = v;
+}
+
+rocksdb_backup_engine_t* gorocksdb_backup_engine_open_opts(
+    const rocksdb_options_t* db_options, const gorocksdb_backup_engine_options_t* opts, char** errptr) {
+    BackupEngine* be;
+    if (gorocksdb_save_error(errptr, BackupEngine::Open(db_options->rep.env, opts->rep, &be))) {
+        return nullptr;
+    }
+    return new rocksdb_backup_engine_t{be};
+}
+
+void gorocksdb_backup_engine_create_new_backup_with_metadata(
+    rocksdb_backup_engine_t* be, rocksdb_t* db, const char* app_metadata, size_t app_metadata_len,
+    unsigned char flush_before_backup, char** errptr) {
+    gorocksdb_save_error(errptr, be->rep->CreateNewBackupWithMetadata(
+        db->rep, std::string(app_metadata, app_metadata_len), flush_before_backup));
+}
+
+void gorocksdb_backup_engine_delete_backup(rocksdb_backup_engine_t* be, uint32_t backup_id, char** errptr) {
+    gorocksdb_save_error(errptr, be->rep->DeleteBackup(backup_id));
+}
+
+const char* gorocksdb_backup_engine_info_app_metadata(
+    const rocksdb_backup_engine_info_t* info, int index, size_t* len) {
+    const std::string& app_metadata = info->rep[index].app_metadata;
+    *len = app_metadata.size();
+    return app_metadata.data();
+}
+
+/* Export and import of column families */
+
+gorocksdb_export_import_files_metadata_t* gorocksdb_checkpoint_export_column_family(
+    rocksdb_checkpoint_t* checkpoint, rocksdb_column_family_handle_t* column_family, const char* export_dir,
+    char** errptr) {
+    ExportImportFilesMetaData* metadata = nullptr;
+    if (gorocksdb_save_error(errptr, checkpoint->rep->ExportColumnFamily(column_family->rep, export_dir, &metadata))) {
+        return nullptr;
+    }
+    gorocksdb_export_import_files_metadata_t* result = new gorocksdb_export_import_files_metadata_t{*metadata};
+    delete metadata;
+    return result;
+}
+
+rocksdb_column_family_handle_t* gorocksdb_create_column_family_with_import(
+    rocksdb_t* db, const rocksdb_options_t* column_family_options, const char* column_family_name,
+    const gorocksdb_export_import_files_metadata_t* metadata, char** errptr) {
+    ColumnFamilyHandle* handle = nullptr;
+    if (gorocksdb_save_error(errptr, db->rep->CreateColumnFamilyWithImport(
+            rocksdb::ColumnFamilyOptions(column_family_options->rep), column_family_name,
+            ImportColumnFamilyOptions(), metadata->rep, &handle))) {
+        return nullptr;
+    }
+    return new rocksdb_column_family_handle_t{handle};
+}
+
+gorocksdb_export_import_files_metadata_t* gorocksdb_export_import_files_metadata_create(
+    const char* db_comparator_name) {
+    gorocksdb_export_import_files_metadata_t* metadata = new gorocksdb_export_import_files_metadata_t;
+    metadata->rep.db_comparator_name = db_comparator_name;
+    return metadata;
+}
+
+void gorocksdb_export_import_files_metadata_destroy(gorocksdb_export_import_files_metadata_t* metadata) {
+    delete metadata;
+}
+
+const char* gorocksdb_export_import_files_metadata_db_comparator_name(
+    const gorocksdb_export_import_files_metadata_t* metadata) {
+    return metadata->rep.db_comparator_name.c_str();
+}
+
+int gorocksdb_export_import_files_metadata_count(const gorocksdb_export_import_files_metadata_t* metadata) {
+    return static_cast<int>(metadata->rep.files.size());
+}
+
+void gorocksdb_export_import_files_metadata_add_file(
+    gorocksdb_export_import_files_metadata_t* metadata, const char* name, const char* db_path,
+    const char* column_family_name, int level, uint64_t size,
+    const char* smallest_key, size_t smallest_key_len, const char* largest_key, size_t largest_key_len,
+    uint64_t smallest_seqno, uint64_t largest_seqno, uint64_t num_entries, uint64_t num_deletions) {
+    LiveFileMetaData file;
+    file.name = name;
+    file.db_path = db_path;
+    file.column_family_name = column_family_name;
+    file.level = level;
+    file.size = size;
+    file.smallestkey = std::string(smallest_key, smallest_key_len);
+    file.largestkey = std::string(largest_key, largest_key_len);
+    file.smallest_seqno = smallest_seqno;
+    file.largest_seqno = largest_seqno;
+    file.num_entries = num_entries;
+    file.num_deletions = num_deletions;
+    metadata->rep.files.push_back(file);
+}
+
+const char* gorocksdb_export_import_files_metadata_file_name(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index) {
+    return metadata->rep.files[index].name.c_str();
+}
+
+const char* gorocksdb_export_import_files_metadata_file_db_path(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index) {
+    return metadata->rep.files[index].db_path.c_str();
+}
+
+const char* gorocksdb_export_import_files_metadata_file_column_family_name(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index) {
+    return metadata->rep.files[index].column_family_name.c_str();
+}
+
+int gorocksdb_export_import_files_metadata_file_level(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index) {
+    return metadata->rep.files[index].level;
+}
+
+uint64_t gorocksdb_export_import_files_metadata_file_size(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index) {
+    return metadata->rep.files[index].size;
+}
+
+const char* gorocksdb_export_import_files_metadata_file_smallest_key(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index, size_t* len) {
+    const std::string& key = metadata->rep.files[index].smallestkey;
+    *len = key.size();
+    return key.data();
+}
+
+const char* gorocksdb_export_import_files_metadata_file_largest_key(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index, size_t* len) {
+    const std::string& key = metadata->rep.files[index].largestkey;
+    *len = key.size();
+    return key.data();
+}
+
+uint64_t gorocksdb_export_import_files_metadata_file_smallest_seqno(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index) {
+    return metadata->rep.files[index].smallest_seqno;
+}
+
+uint64_t gorocksdb_export_import_files_metadata_file_largest_seqno(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index) {
+    return metadata->rep.files[index].largest_seqno;
+}
+
+uint64_t gorocksdb_export_import_files_metadata_file_num_entries(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index) {
+    return metadata->rep.files[index].num_entries;
+}
+
+uint64_t gorocksdb_export_import_files_metadata_file_num_deletions(
+    const gorocksdb_export_import_files_metadata_t* metadata, int index) {
+    return metadata->rep.files[index].num_deletions;
+}
+
+/* External file ingestion */
+
+void gorocksdb_ingest_external_files(
+    rocksdb_t* db, int num_args, rocksdb_column_family_handle_t* const* column_families,
+    const rocksdb_ingestexternalfileoptions_t* const* options, const size_t* num_files,
+    const char* const* file_paths, char** errptr) {
+    std::vector<IngestExternalFileArg> args(num_args);
+    for (int i = 0; i < num_args; i++) {
+        args[i].column_family = column_families[i]->rep;
+        args[i].options = options[i]->rep;
+        for (size_t j = 0; j < num_files[i]; j++) {
+            args[i].external_files.push_back(*file_paths++);
+        }
+    }
+    gorocksdb_save_error(errptr, db->rep->IngestExternalFiles(args));
+}
+
+void gorocksdb_ingestexternalfileoptions_set_fail_if_not_bottommost_level(
+    rocksdb_ingestexternalfileoptions_t* opts, unsigned char v) {
+    opts->rep.fail_if_not_bottommost_level = v;
+}
+
+void gorocksdb_ingestexternalfileoptions_set_verify_checksums_before_ingest(
+    rocksdb_ingestexternalfileoptions_t* opts, unsigned char v) {
+    opts->rep.verify_checksums_before_ingest = v;
+}
+
+void gorocksdb_ingestexternalfileoptions_set_write_global_seqno(
+    rocksdb_ingestexternalfileoptions_t* opts, unsigned char v) {
+    opts->rep.write_global_seqno = v;
+}
+
+/* File metadata */
+
+const char* gorocksdb_livefiles_column_family_name(const rocksdb_livefiles_t* lf, int index) {
+    return lf->rep[index].column_family_name.c_str();
+}
+
+const char* gorocksdb_livefiles_db_path(const rocksdb_livefiles_t* lf, int index) {
+    return lf->rep[index].db_path.c_str();
+}
+
+uint64_t gorocksdb_livefiles_smallest_seqno(const rocksdb_livefiles_t* lf, int index) {
+    return lf->rep[index].smallest_seqno;
+}
+
+uint64_t gorocksdb_livefiles_largest_seqno(const rocksdb_livefiles_t* lf, int index) {
+    return lf->rep[index].largest_seqno;
+}
+
+unsigned char gorocksdb_livefiles_being_compacted(const rocksdb_livefiles_t* lf, int index) {
+    return lf->rep[index].being_compacted;
+}
+
+gorocksdb_column_family_metadata_t* gorocksdb_get_column_family_metadata(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family) {
+    ColumnFamilyHandle* cf = column_family != nullptr ? column_family->rep : db->rep->DefaultColumnFamily();
+    gorocksdb_column_family_metadata_t* cf_meta = new gorocksdb_column_family_metadata_t;
+    db->rep->GetColumnFamilyMetaData(cf, &cf_meta->rep);
+    return cf_meta;
+}
+
+const char* gorocksdb_column_family_metadata_name(const gorocksdb_column_family_metadata_t* cf_meta) {
+    return cf_meta->rep.name.c_str();
+}
+
+uint64_t gorocksdb_column_family_metadata_size(const gorocksdb_column_family_metadata_t* cf_meta) {
+    return cf_meta->rep.size;
+}
+
+size_t gorocksdb_column_family_metadata_file_count(const gorocksdb_column_family_metadata_t* cf_meta) {
+    return cf_meta->rep.file_count;
+}
+
+int gorocksdb_column_family_metadata_level_count(const gorocksdb_column_family_metadata_t* cf_meta) {
+    return static_cast<int>(cf_meta->rep.levels.size());
+}
+
+int gorocksdb_column_family_metadata_level(const gorocksdb_column_family_metadata_t* cf_meta, int level_index) {
+    return cf_meta->rep.levels[level_index].level;
+}
+
+uint64_t gorocksdb_column_family_metadata_level_size(
+    const gorocksdb_column_family_metadata_t* cf_meta, int level_index) {
+    return cf_meta->rep.levels[level_index].size;
+}
+
+int gorocksdb_column_family_metadata_level_file_count(
+    const gorocksdb_column_family_metadata_t* cf_meta, int level_index) {
+    return static_cast<int>(cf_meta->rep.levels[level_index].files.size());
+}
+
+void gorocksdb_column_family_metadata_level_file(
+    const gorocksdb_column_family_metadata_t* cf_meta, int level_index, int file_index,
+    gorocksdb_sst_file_metadata_t* file) {
+    const rocksdb::SstFileMetaData& f = cf_meta->rep.levels[level_index].files[file_index];
+    file->name = f.name.c_str();
+    file->db_path = f.db_path.c_str();
+    file->size = f.size;
+    file->smallest_key = f.smallestkey.data();
+    file->smallest_key_len = f.smallestkey.size();
+    file->largest_key = f.largestkey.data();
+    file->largest_key_len = f.largestkey.size();
+    file->smallest_seqno = f.smallest_seqno;
+    file->largest_seqno = f.largest_seqno;
+    file->being_compacted = f.being_compacted;
+    file->num_entries = f.num_entries;
+    file->num_deletions = f.num_deletions;
+}
+
+void gorocksdb_column_family_metadata_destroy(gorocksdb_column_family_metadata_t* cf_meta) {
+    delete cf_meta;
+}
+
+/* Compaction */
+
+void gorocksdb_compactoptions_set_target_path_id(rocksdb_compactoptions_t* opts, uint32_t v) {
+    opts->rep.target_path_id = v;
+}
+
+void gorocksdb_compact_files(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, const char* const* file_names,
+    size_t num_files, int output_level, char** errptr) {
+    ColumnFamilyHandle* cf = column_family != nullptr ? column_family->rep : db->rep->DefaultColumnFamily();
+    std::vector<std::string> names(file_names, file_names + num_files);
+    gorocksdb_save_error(errptr, db->rep->CompactFiles(CompactionOptions(), cf, names, output_level));
+}
+
+/* Deletion of files */
+
+void gorocksdb_delete_files_in_ranges(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, int num_ranges,
+    const char* const* range_start_key, const size_t* range_start_key_len,
+    const char* const* range_limit_key, const size_t* range_limit_key_len,
+    unsigned char include_end, char** errptr) {
+    ColumnFamilyHandle* cf = column_family != nullptr ? column_family->rep : db->rep->DefaultColumnFamily();
+    // a missing start or limit key leaves the range unbounded on that side
+    std::vector<Slice> starts(num_ranges);
+    std::vector<Slice> limits(num_ranges);
+    std::vector<rocksdb::RangePtr> ranges(num_ranges);
+    for (int i = 0; i < num_ranges; i++) {
+        if (range_start_key[i] != nullptr) {
+            starts[i] = Slice(range_start_key[i], range_start_key_len[i]);
+            ranges[i].start = &starts[i];
+        }
+        if (range_limit_key[i] != nullptr) {
+            limits[i] = Slice(range_limit_key[i], range_limit_key_len[i]);
+            ranges[i].limit = &limits[i];
+        }
+    }
+    gorocksdb_save_error(
+        errptr, rocksdb::DeleteFilesInRanges(db->rep, cf, ranges.data(), ranges.size(), include_end));
+}
+
+/* Table properties */
+
+void gorocksdb_table_properties_get_data(
+    const gorocksdb_table_properties_t* props, gorocksdb_table_properties_data_t* data) {
+    const TableProperties& p = *props->rep;
+    data->data_size = p.data_size;
+    data->index_size = p.index_size;
+    data->filter_size = p.filter_size;
+    data->raw_key_size = p.raw_key_size;
+    data->raw_value_size = p.raw_value_size;
+    data->num_data_blocks = p.num_data_blocks;
+    data->num_entries = p.num_entries;
+    data->num_deletions = p.num_deletions;
+    data->num_merge_operands = p.num_merge_operands;
+    data->num_range_deletions = p.num_range_deletions;
+    data->format_version = p.format_version;
+    data->creation_time = p.creation_time;
+    data->oldest_key_time = p.oldest_key_time;
+    data->file_creation_time = p.file_creation_time;
+    data->column_family_name = p.column_family_name.c_str();
+    data->comparator_name = p.comparator_name.c_str();
+    data->merge_operator_name = p.merge_operator_name.c_str();
+    data->prefix_extractor_name = p.prefix_extractor_name.c_str();
+    data->compression_name = p.compression_name.c_str();
+    data->num_user_collected_properties = p.user_collected_properties.size();
+}
+
+void gorocksdb_table_properties_get_user_collected(
+    const gorocksdb_table_properties_t* props, const char** keys, size_t* key_lens,
+    const char** values, size_t* value_lens) {
+    size_t i = 0;
+    for (const auto& prop : props->rep->user_collected_properties) {
+        keys[i] = prop.first.data();
+        key_lens[i] = prop.first.size();
+        values[i] = prop.second.data();
+        value_lens[i] = prop.second.size();
+        i++;
+    }
+}
+
+void gorocksdb_table_properties_destroy(gorocksdb_table_properties_t* props) {
+    delete props;
+}
+
+static gorocksdb_table_properties_collection_t* gorocksdb_table_properties_collection_create(
+    const rocksdb::TablePropertiesCollection& props) {
+    gorocksdb_table_properties_collection_t* collection = new gorocksdb_table_properties_collection_t;
+    collection->rep.assign(props.begin(), props.end());
+    return collection;
+}
+
+gorocksdb_table_properties_collection_t* gorocksdb_get_properties_of_all_tables(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, char** errptr) {
+    ColumnFamilyHandle* cf = column_family != nullptr ? column_family->rep : db->rep->DefaultColumnFamily();
+    rocksdb::TablePropertiesCollection props;
+    if (gorocksdb_save_error(errptr, db->rep->GetPropertiesOfAllTables(cf, &props))) {
+        return nullptr;
+    }
+    return gorocksdb_table_properties_collection_create(props);
+}
+
+gorocksdb_table_properties_collection_t* gorocksdb_get_properties_of_tables_in_range(
+    rocksdb_t* db, rocksdb_column_family_handle_t* column_family, int num_ranges,
+    const char* const* range_start_key, const size_t* range_start_key_len,
+    const char* const* range_limit_key, const size_t* range_limit_key_len, char** errptr) {
+    ColumnFamilyHandle* cf = column_family != nullptr ? column_family->rep : db->rep->DefaultColumnFamily();
+    std::vector<rocksdb::Range> ranges(num_ranges);
+    for (int i = 0; i < num_ranges; i++) {
+        ranges[i].start = Slice(range_start_key[i], range_start_key_len[i]);
+        ranges[i].limit = Slice(range_limit_key[i], range_limit_key_len[i]);
+    }
+    rocksdb::TablePropertiesCollection props;
+    if (gorocksdb_save_error(errptr, db->rep->GetPropertiesOfTablesInRange(cf, ranges.data(), ranges.size(), &props))) {
+        return nullptr;
+    }
+    return gorocksdb_table_properties_collection_create(props);
+}
+
+int gorocksdb_table_properties_collection_count(const gorocksdb_table_properties_collection_t* collection) {
+    return static_cast<int>(collection->rep.size());
+}
+
+const char* gorocksdb_table_properties_collection_name(
+    const gorocksdb_table_properties_collection_t* collection, int index) {
+    return collection->rep[index].first.c_str();
+}
+
+gorocksdb_table_properties_t* gorocksdb_table_properties_collection_properties(
+    const gorocksdb_table_properties_collection_t* collection, int index) {
+    return new gorocksdb_table_properties_t{collection->rep[index].second};
+}
+
+void gorocksdb_table_properties_collection_destroy(gorocksdb_table_properties_collection_t* collection) {
+    delete collection;
+}
+
+/* SST File Reader */
+
+gorocksdb_sstfilereader_t* gorocksdb_sstfilereader_create(const rocksdb_options_t* options) {
+    return new gorocksdb_sstfilereader_t{new SstFileReader(options->rep)};
+}
+
+void gorocksdb_sstfilereader_open(gorocksdb_sstfilereader_t* reader, const char* file_path, char** errptr) {
+    gorocksdb_save_error(errptr, reader->rep->Open(file_path));
+}
+
+rocksdb_iterator_t* gorocksdb_sstfilereader_new_iterator(
+    gorocksdb_sstfilereader_t* reader, const rocksdb_readoptions_t* options) {
+    return new rocksdb_iterator_t{reader->rep->NewIterator(options->rep)};
+}
+
+gorocksdb_table_properties_t* gorocksdb_sstfilereader_get_table_properties(gorocksdb_sstfilereader_t* reader) {
+    return new gorocksdb_table_properties_t{reader->rep->GetTableProperties()};
+}
+
+void gorocksdb_sstfilereader_verify_checksum(gorocksdb_sstfilereader_t* reader, char** errptr) {
+    gorocksdb_save_error(errptr, reader->rep->VerifyChecksum());
+}
+
+void gorocksdb_sstfilereader_destroy(gorocksdb_sstfilereader_t* reader) {
+    delete reader->rep;
+    delete reader;
+}
+
+/* TransactionDB */
+
+rocksdb_t* gorocksdb_transactiondb_get_base_db(rocksdb_transactiondb_t* txn_db) {
+    rocksdb_t* base_db = new rocksdb_t;
+    base_db->rep = txn_db->rep;
+    return base_db;
+}
+
+void gorocksdb_transactiondb_close_base_db(rocksdb_t* base_db) {
+    delete base_db;
+}
+
+/* Transaction */
+
+void gorocksdb_transaction_singledelete(
+    rocksdb_transaction_t* txn, rocksdb_column_family_handle_t* column_family, const char* key, size_t klen,
+    char** errptr) {
+    if (column_family != nullptr) {
+        gorocksdb_save_error(errptr, txn->rep->SingleDelete(column_family->rep, Slice(key, klen)));
+    } else {
+        gorocksdb_save_error(errptr, txn->rep->SingleDelete(Slice(key, klen)));
+    }
+}
+
+/* Event Listener */
+
+static int gorocksdb_flush_reason(FlushReason reason) {
+    switch (reason) {
+        case FlushReason::kGetLiveFiles: return gorocksdb_flush_reason_get_live_files;
+        case FlushReason::kShutDown: return gorocksdb_flush_reason_shutdown;
+        case FlushReason::kExternalFileIngestion: return gorocksdb_flush_reason_external_file_ingestion;
+        case FlushReason::kManualCompaction: return gorocksdb_flush_reason_manual_compaction;
+        case FlushReason::kWriteBufferManager: return gorocksdb_flush_reason_write_buffer_manager;
+        case FlushReason::kWriteBufferFull: return gorocksdb_flush_reason_write_buffer_full;
+        case FlushReason::kTest: return gorocksdb_flush_reason_test;
+        case FlushReason::kDeleteFiles: return gorocksdb_flush_reason_delete_files;
+        case FlushReason::kAutoCompaction: return gorocksdb_flush_reason_auto_compaction;
+        case FlushReason::kManualFlush: return gorocksdb_flush_reason_manual_flush;
+        case FlushReason::kErrorRecovery: return gorocksdb_flush_reason_error_recovery;
+        default: return gorocksdb_flush_reason_others;
+    }
+}
+
+static int gorocksdb_compaction_reason(CompactionReason reason) {
+    switch (reason) {
+        case CompactionReason::kLevelL0FilesNum: return gorocksdb_compaction_reason_level_l0_files_num;
+        case CompactionReason::kLevelMaxLevelSize: return gorocksdb_compaction_reason_level_max_level_size;
+        case CompactionReason::kUniversalSizeAmplification: return gorocksdb_compaction_reason_universal_size_amplification;
+        case CompactionReason::kUniversalSizeRatio: return gorocksdb_compaction_reason_universal_size_ratio;
+        case CompactionReason::kUniversalSortedRunNum: return gorocksdb_compaction_reason_universal_sorted_run_num;
+        case CompactionReason::kFIFOMaxSize: return gorocksdb_compaction_reason_fifo_max_size;
+        case CompactionReason::kFIFOReduceNumFiles: return gorocksdb_compaction_reason_fifo_reduce_num_files;
+        case CompactionReason::kFIFOTtl: return gorocksdb_compaction_reason_fifo_ttl;
+        case CompactionReason::kManualCompaction: return gorocksdb_compaction_reason_manual_compaction;
+        case CompactionReason::kFilesMarkedForCompaction: return gorocksdb_compaction_reason_files_marked_for_compaction;
+        case CompactionReason::kBottommostFiles: return gorocksdb_compaction_reason_bottommost_files;
+        case CompactionReason::kTtl: return gorocksdb_compaction_reason_ttl;
+        case CompactionReason::kFlush: return gorocksdb_compaction_reason_flush;
+        case CompactionReason::kExternalSstIngestion: return gorocksdb_compaction_reason_external_sst_ingestion;
+        case CompactionReason::kPeriodicCompaction: return gorocksdb_compaction_reason_periodic_compaction;
+        default: return gorocksdb_compaction_reason_unknown;
+    }
+}
+
+static int gorocksdb_write_stall_condition(WriteStallCondition condition) {
+    switch (condition) {
+        case WriteStallCondition::kDelayed: return gorocksdb_write_stall_condition_delayed;
+        case WriteStallCondition::kStopped: return gorocksdb_write_stall_condition_stopped;
+        default: return gorocksdb_write_stall_condition_normal;
+    }
+}
+
+static int gorocksdb_background_error_reason(BackgroundErrorReason reason) {
+    switch (reason) {
+        case BackgroundErrorReason::kFlush: return gorocksdb_background_error_reason_flush;
+        case BackgroundErrorReason::kCompaction: return gorocksdb_background_error_reason_compaction;
+        case BackgroundErrorReason::kWriteCallback: return gorocksdb_background_error_reason_write_callback;
+        case BackgroundErrorReason::kMemTable: return gorocksdb_background_error_reason_memtable;
+        default: return gorocksdb_background_error_reason_other;
+    }
+}
+
+static int gorocksdb_table_file_creation_reason(TableFileCreationReason reason) {
+    switch (reason) {
+        case TableFileCreationReason::kFlush: return gorocksdb_table_file_creation_reason_flush;
+        case TableFileCreationReason::kCompaction: return gorocksdb_table_file_creation_reason_compaction;
+        case TableFileCreationReason::kRecovery: return gorocksdb_table_file_creation_reason_recovery;
+        default: return gorocksdb_table_file_creation_reason_misc;
+    }
+}
+
+static std::vector<const char*> gorocksdb_c_strings(const std::vector<std::string>& strs) {
+    std::vector<const char*> result;
+    result.reserve(strs.size());
+    for (const auto& str : strs) {
+        result.push_back(str.c_str());
+    }
+    return result;
+}
+
+// GoEventListener forwards the events to the Go EventListener registered
+// at the index.
+class GoEventListener : public rocksdb::EventListener {
+ public:
+    explicit GoEventListener(uintptr_t idx) : idx_(idx) {}
+
+    void OnFlushCompleted(DB* /*db*/, const FlushJobInfo& info) override {
+        gorocksdb_flushjobinfo_t cinfo;
+        cinfo.cf_name = info.cf_name.c_str();
+        cinfo.file_path = info.file_path.c_str();
+        cinfo.thread_id = info.thread_id;
+        cinfo.job_id = info.job_id;
+        cinfo.triggered_writes_slowdown = info.triggered_writes_slowdown;
+        cinfo.triggered_writes_stop = info.triggered_writes_stop;
+        cinfo.smallest_seqno = info.smallest_seqno;
+        cinfo.largest_seqno = info.largest_seqno;
+        cinfo.flush_reason = gorocksdb_flush_reason(info.flush_reason);
+        gorocksdb_eventlistener_flush_completed(idx_, &cinfo);
+    }
+
+    void OnCompactionCompleted(DB* /*db*/, const CompactionJobInfo& info) override {
+        std::string status = info.status.ToString();
+        std::vector<const char*> input_files = gorocksdb_c_strings(info.input_files);
+        std::vector<const char*> output_files = gorocksdb_c_strings(info.output_files);
+
+        gorocksdb_compactionjobinfo_t cinfo;
+        cinfo.cf_name = info.cf_name.c_str();
+        cinfo.status = info.status.ok() ? nullptr : status.c_str();
+        cinfo.thread_id = info.thread_id;
+        cinfo.job_id = info.job_id;
+        cinfo.base_input_level = info.base_input_level;
+        cinfo.output_level = info.output_level;
+        cinfo.input_files = input_files.data();
+        cinfo.num_input_files = input_files.size();
+        cinfo.output_files = output_files.data();
+        cinfo.num_output_files = output_files.size();
+        cinfo.compaction_reason = gorocksdb_compaction_reason(info.compaction_reason);
+        cinfo.elapsed_micros = info.stats.elapsed_micros;
+        cinfo.num_input_records = info.stats.num_input_records;
+        cinfo.num_output_records = info.stats.num_output_records;
+        cinfo.total_input_bytes = info.stats.total_input_bytes;
+        cinfo.total_output_bytes = info.stats.total_output_bytes;
+        gorocksdb_eventlistener_compaction_completed(idx_, &cinfo);
+    }
+
+    void OnStallConditionsChanged(const WriteStallInfo& info) override {
+        gorocksdb_writestallinfo_t cinfo;
+        cinfo.cf_name = info.cf_name.c_str();
+        cinfo.cur = gorocksdb_write_stall_condition(info.condition.cur);
+        cinfo.prev = gorocksdb_write_stall_condition(info.condition.prev);
+        gorocksdb_eventlistener_stall_conditions_changed(idx_, &cinfo);
+    }
+
+    void OnBackgroundError(BackgroundErrorReason reason, Status* bg_error) override {
+        std::string status = bg_error->ToString();
+        gorocksdb_eventlistener_background_error(
+            idx_, gorocksdb_background_error_reason(reason), const_cast<char*>(status.c_str()));
+    }
+
+    void OnTableFileCreated(const TableFileCreationInfo& info) override {
+        std::string status = info.status.ToString();
+
+        gorocksdb_tablefilecreationinfo_t cinfo;
+        cinfo.db_name = info.db_name.c_str();
+        cinfo.cf_name = info.cf_name.c_str();
+        cinfo.file_path = info.file_path.c_str();
+        cinfo.file_size = info.file_size;
+        cinfo.job_id = info.job_id;
+        cinfo.reason = gorocksdb_table_file_creation_reason(info.reason);
+        cinfo.status = info.status.ok() ? nullptr : status.c_str();
+        gorocksdb_eventlistener_table_file_created(idx_, &cinfo);
+    }
+
+    void OnTableFileDeleted(const TableFileDeletionInfo& info) override {
+        std::string status = info.status.ToString();
+
+        gorocksdb_tablefiledeletioninfo_t cinfo;
+        cinfo.db_name = info.db_name.c_str();
+        cinfo.file_path = info.file_path.c_str();
+        cinfo.job_id = info.job_id;
+        cinfo.status = info.status.ok() ? nullptr : status.c_str();
+        gorocksdb_eventlistener_table_file_deleted(idx_, &cinfo);
+    }
+
+ private:
+    GoInt idx_;
+};
+
+void gorocksdb_options_add_eventlistener(rocksdb_options_t* opts, uintptr_t idx) {
+    opts->rep.listeners.push_back(std::make_shared<GoEventListener>(idx));
+}
+
+/* Logger */
+
+// GoLogger forwards the info log messages to the Go Logger registered at the
+// index.
+class GoLogger : public rocksdb::Logger {
+ public:
+    GoLogger(uintptr_t idx, InfoLogLevel level) : rocksdb::Logger(level), idx_(idx) {}
+
+    using rocksdb::Logger::Logv;
+
+    void Logv(const char* format, va_list ap) override {
+        Logv(InfoLogLevel::INFO_LEVEL, format, ap);
+    }
+
+    void Logv(const InfoLogLevel level, const char* format, va_list ap) override {
+        if (level < GetInfoLogLevel() && level != InfoLogLevel::HEADER_LEVEL) {
+            return;
+        }
+        va_list ap_copy;
+        va_copy(ap_copy, ap);
+        char buf[512];
+        int n = vsnprintf(buf, sizeof(buf), format, ap);
+        std::string msg;
+        if (n >= 0 && static_cast<size_t>(n) < sizeof(buf)) {
+            msg.assign(buf, n);
+        } else if (n >= 0) {
+            msg.resize(n + 1);
+            vsnprintf(&msg[0], msg.size(), format, ap_copy);
+            msg.resize(n);
+        }
+        va_end(ap_copy);
+        if (n < 0) {
+            return;
+        }
+        gorocksdb_logger_log(idx_, static_cast<int>(level), const_cast<char*>(msg.data()), msg.size());
+    }
+
+ private:
+    GoInt idx_;
+};
+
+void gorocksdb_options_set_info_logger(rocksdb_options_t* opts, uintptr_t idx) {
+    opts->rep.info_log = std::make_shared<GoLogger>(idx, opts->rep.info_log_level);
+}
+
+void gorocksdb_options_sync_info_logger_level(rocksdb_options_t* opts) {
+    if (dynamic_cast<GoLogger*>(opts->rep.info_log.get()) != nullptr) {
+        opts->rep.info_log->SetInfoLogLevel(opts->rep.info_log_level);
+    }
+}
+
+/* Table Properties Collector */
+
+static int gorocksdb_entry_type(rocksdb::EntryType type) {
+    switch (type) {
+        case rocksdb::kEntryPut: return gorocksdb_entry_put;
+        case rocksdb::kEntryDelete: return gorocksdb_entry_delete;
+        case rocksdb::kEntrySingleDelete: return gorocksdb_entry_single_delete;
+        case rocksdb::kEntryMerge: return gorocksdb_entry_merge;
+        case rocksdb::kEntryRangeDeletion: return gorocksdb_entry_range_deletion;
+        case rocksdb::kEntryBlobIndex: return gorocksdb_entry_blob_index;
+        default: return gorocksdb_entry_other;
+    }
+}
+
+static Status gorocksdb_collector_status(char* err) {
+    if (err == nullptr) {
+        return Status::OK();
+    }
+    Status s = Status::Corruption(err);
+    free(err);
+    return s;
+}
+
+void gorocksdb_user_collected_properties_add(
+    gorocksdb_user_collected_properties_t* props, const char* key, size_t key_len,
+    const char* value, size_t value_len) {
+    (*props->rep)[std::string(key, key_len)] = std::string(value, value_len);
+}
+
+// GoTablePropertiesCollector forwards the entries of a table file to a Go
+// TablePropertiesCollector, identified by its handle.
+class GoTablePropertiesCollector : public rocksdb::TablePropertiesCollector {
+ public:
+    explicit GoTablePropertiesCollector(uintptr_t handle) : handle_(handle) {
+        char* name = gorocksdb_tablepropertiescollector_name(handle_);
+        name_ = name;
+        free(name);
+    }
+
+    ~GoTablePropertiesCollector() override {
+        gorocksdb_tablepropertiescollector_destroy(handle_);
+    }
+
+    Status AddUserKey(const Slice& key, const Slice& value, rocksdb::EntryType type,
+                      rocksdb::SequenceNumber seq, uint64_t file_size) override {
+        return gorocksdb_collector_status(gorocksdb_tablepropertiescollector_add_user_key(
+            handle_, const_cast<char*>(key.data()), key.size(), const_cast<char*>(value.data()), value.size(),
+            gorocksdb_entry_type(type), seq, file_size));
+    }
+
+    Status Finish(rocksdb::UserCollectedProperties* properties) override {
+        gorocksdb_user_collected_properties_t props{properties};
+        return gorocksdb_collector_status(gorocksdb_tablepropertiescollector_finish(handle_, &props));
+    }
+
+    rocksdb::UserCollectedProperties GetReadableProperties() const override {
+        return rocksdb::UserCollectedProperties();
+    }
+
+    const char* Name() const override { return name_.c_str(); }
+
+ private:
+    GoUintptr handle_;
+    std::string name_;
+};
+
+// GoTablePropertiesCollectorFactory creates the collectors of the Go
+// TablePropertiesCollectorFactory registered at the index.
+class GoTablePropertiesCollectorFactory : public rocksdb::TablePropertiesCollectorFactory {
+ public:
+    explicit GoTablePropertiesCollectorFactory(uintptr_t idx) : idx_(idx) {}
+
+    rocksdb::TablePropertiesCollector* CreateTablePropertiesCollector(
+        rocksdb::TablePropertiesCollectorFactory::Context context) override {
+        return new GoTablePropertiesCollector(
+            gorocksdb_tablepropertiescollectorfactory_create(idx_, context.column_family_id));
+    }
+
+    const char* Name() const override {
+        return gorocksdb_tablepropertiescollectorfactory_name(idx_);
+    }
+
+ private:
+    GoInt idx_;
+};
+
+void gorocksdb_options_add_table_properties_collector_factory(rocksdb_options_t* opts, uintptr_t idx) {
+    opts->rep.table_properties_collector_factories.push_back(
+        std::make_shared<GoTablePropertiesCollectorFactory>(idx));
+}
@@ -5,7 +5,7 @@ package gorocksdb
 import "C"
 import (
 	"bytes"
-	"errors"
+	"context"
 	"unsafe"
 )
 
@@ -28,23 +28,35 @@ import (
 //
 type Iterator struct {
 	c *C.rocksdb_iterator_t
+
+	// ctx is checked by Valid for iterators created with a context.
+	ctx    context.Context
+	ctxErr error
 }
 
 // NewNativeIterator creates a Iterator object.
 func NewNativeIterator(c unsafe.Pointer) *Iterator {
-	return &Iterator{(*C.rocksdb_iterator_t)(c)}
+	return &Iterator{c: (*C.rocksdb_iterator_t)(c)}
 }
 
 // Valid returns false only when an Iterator has iterated past either the
-// first or the last key in the database.
+// first or the last key in the database. An iterator created with a context
+// is also invalid once the context is done, Err returns the error of the
+// context then.
 func (iter *Iterator) Valid() bool {
+	if iter.ctx != nil && iter.ctxErr == nil {
+		iter.ctxErr = iter.ctx.Err()
+	}
+	if iter.ctxErr != nil {
+		return false
+	}
 	return C.rocksdb_iter_valid(iter.c) != 0
 }
 
 // ValidForPrefix returns false only when an Iterator has iterated past the
 // first or the last key in the database or the specified prefix.
 func (iter *Iterator) ValidForPrefix(prefix []byte) bool {
-	if C.rocksdb_iter_valid(iter.c) == 0 {
+	if !iter.Valid() {
 		return false
 	}
 
@@ -108,19 +120,25 @@ func (iter *Iterator) SeekForPrev(key []byte) {
 }
 
 // Err returns nil if no errors happened during iteration, or the actual
-// error otherwise.
+// error otherwise. For an iterator created with a context, this is
+// context.Canceled or context.DeadlineExceeded if the iteration was stopped
+// because the context is done.
 func (iter *Iterator) Err() error {
 	var cErr *C.char
 	C.rocksdb_iter_get_error(iter.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
-	return nil
+	return iter.ctxErr
 }
 
-// Close closes the iterator.
+// Close closes the iterator. Closing an iterator which is already closed has
+// no effect.
 func (iter *Iterator) Close() {
+	if iter.c == nil {
+		return
+	}
 	C.rocksdb_iter_destroy(iter.c)
 	iter.c = nil
 }
@@ -0,0 +1,38 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+import "strings"
+
+// A Logger receives the info log messages of RocksDB, see
+// Options.SetInfoLogger.
+//
+// Logf is called from foreground and background threads of RocksDB,
+// possibly concurrently. It must not call back into the database.
+type Logger interface {
+	// Logf logs a message of the given level. The message is formatted
+	// already and has no trailing newline.
+	Logf(level InfoLogLevel, msg string)
+}
+
+// LoggerFunc is an adapter to use a function as Logger.
+type LoggerFunc func(level InfoLogLevel, msg string)
+
+// Logf calls f(level, msg).
+func (f LoggerFunc) Logf(level InfoLogLevel, msg string) {
+	f(level, msg)
+}
+
+// Hold references to loggers.
+var loggers = NewCOWList()
+
+func registerLogger(logger Logger) int {
+	return loggers.Append(logger)
+}
+
+//export gorocksdb_logger_log
+func gorocksdb_logger_log(idx int, cLevel C.int, cMsg *C.char, cMsgLen C.size_t) {
+	msg := strings.TrimRight(C.GoStringN(cMsg, C.int(cMsgLen)), "\n")
+	loggers.Get(idx).(Logger).Logf(InfoLogLevel(cLevel), msg)
+}
@@ -0,0 +1,47 @@
+//go:build go1.21
+// +build go1.21
+
+package gorocksdb
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLevelFatal is the slog level of messages logged by RocksDB with
+// FatalInfoLogLevel.
+const SlogLevelFatal = slog.LevelError + 4
+
+// NewSlogLogger returns a Logger writing the info log messages of RocksDB to
+// the slog logger. If dbName is not empty, it is attached to every message
+// with the key "db".
+func NewSlogLogger(logger *slog.Logger, dbName string) Logger {
+	if dbName != "" {
+		logger = logger.With(slog.String("db", dbName))
+	}
+	return slogLogger{logger}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) Logf(level InfoLogLevel, msg string) {
+	l.logger.Log(context.Background(), SlogLevel(level), msg)
+}
+
+// SlogLevel returns the slog level matching the info log level.
+func SlogLevel(level InfoLogLevel) slog.Level {
+	switch level {
+	case DebugInfoLogLevel:
+		return slog.LevelDebug
+	case WarnInfoLogLevel:
+		return slog.LevelWarn
+	case ErrorInfoLogLevel:
+		return slog.LevelError
+	case FatalInfoLogLevel:
+		return SlogLevelFatal
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,35 @@
+//go:build go1.21
+// +build go1.21
+
+package gorocksdb
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := NewSlogLogger(slog.New(handler), "/tmp/db")
+
+	logger.Logf(WarnInfoLogLevel, "write stall")
+	logger.Logf(FatalInfoLogLevel, "corruption")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	ensure.DeepEqual(t, len(lines), 2)
+	ensure.True(t, strings.Contains(lines[0], `level=WARN msg="write stall" db=/tmp/db`), lines[0])
+	ensure.True(t, strings.Contains(lines[1], `level=ERROR+4 msg=corruption db=/tmp/db`), lines[1])
+}
+
+func TestSlogLevel(t *testing.T) {
+	ensure.DeepEqual(t, SlogLevel(DebugInfoLogLevel), slog.LevelDebug)
+	ensure.DeepEqual(t, SlogLevel(InfoInfoLogLevel), slog.LevelInfo)
+	ensure.DeepEqual(t, SlogLevel(WarnInfoLogLevel), slog.LevelWarn)
+	ensure.DeepEqual(t, SlogLevel(ErrorInfoLogLevel), slog.LevelError)
+	ensure.DeepEqual(t, SlogLevel(FatalInfoLogLevel), SlogLevelFatal)
+	ensure.DeepEqual(t, SlogLevel(HeaderInfoLogLevel), slog.LevelInfo)
+}
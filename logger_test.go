@@ -0,0 +1,40 @@
+package gorocksdb
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestInfoLogger(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		msgs = make(map[InfoLogLevel][]string)
+	)
+	logger := LoggerFunc(func(level InfoLogLevel, msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		msgs[level] = append(msgs[level], msg)
+	})
+
+	db := newTestDB(t, "TestInfoLogger", func(opts *Options) {
+		opts.SetInfoLogger(logger)
+		opts.SetInfoLogLevel(WarnInfoLogLevel)
+	})
+	db.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	ensure.True(t, len(msgs[HeaderInfoLogLevel]) > 0)
+	ensure.DeepEqual(t, len(msgs[DebugInfoLogLevel]), 0)
+	ensure.DeepEqual(t, len(msgs[InfoInfoLogLevel]), 0)
+
+	var version bool
+	for _, msg := range msgs[HeaderInfoLogLevel] {
+		ensure.False(t, strings.HasSuffix(msg, "\n"))
+		version = version || strings.Contains(msg, "RocksDB version")
+	}
+	ensure.True(t, version)
+}
@@ -0,0 +1,147 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// OptimisticTransactionDB is a reusable handle to a RocksDB optimistic
+// transactional database on disk, created by OpenOptimisticTransactionDb.
+//
+// Optimistic transactions take no locks while they are running. Instead
+// conflicts are detected when the transaction is committed, in which case
+// Transaction.Commit returns an error matching ErrTransactionConflict.
+type OptimisticTransactionDB struct {
+	c    *C.rocksdb_optimistictransactiondb_t
+	name string
+	opts *Options
+}
+
+// OpenOptimisticTransactionDb opens a database with the specified options.
+func OpenOptimisticTransactionDb(opts *Options, name string) (*OptimisticTransactionDB, error) {
+	var (
+		cErr  *C.char
+		cName = C.CString(name)
+	)
+	defer C.free(unsafe.Pointer(cName))
+	db := C.rocksdb_optimistictransactiondb_open(opts.c, cName, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return &OptimisticTransactionDB{
+		name: name,
+		c:    db,
+		opts: opts,
+	}, nil
+}
+
+// OpenOptimisticTransactionDbColumnFamilies opens a database with the specified column families.
+func OpenOptimisticTransactionDbColumnFamilies(
+	opts *Options,
+	name string,
+	cfDescriptors []*ColumnFamilyDescriptor,
+) (*OptimisticTransactionDB, []*ColumnFamilyHandle, error) {
+	numColumnFamilies := len(cfDescriptors)
+	if numColumnFamilies == 0 {
+		return nil, nil, errors.New("must provide the column family names and options")
+	}
+
+	var (
+		cNames = make([]*C.char, numColumnFamilies)
+		cOpts  = make([]*C.rocksdb_options_t, numColumnFamilies)
+	)
+	for i, s := range cfDescriptors {
+		cNames[i] = C.CString(s.Name)
+		cOpts[i] = s.Options.c
+	}
+	defer func() {
+		for _, s := range cNames {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	var (
+		cErr     *C.char
+		cName    = C.CString(name)
+		cHandles = make([]*C.rocksdb_column_family_handle_t, numColumnFamilies)
+	)
+	defer C.free(unsafe.Pointer(cName))
+
+	db := C.rocksdb_optimistictransactiondb_open_column_families(
+		opts.c,
+		cName,
+		C.int(numColumnFamilies),
+		&cNames[0],
+		&cOpts[0],
+		&cHandles[0],
+		&cErr,
+	)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, nil, newError(C.GoString(cErr))
+	}
+
+	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
+	for i, c := range cHandles {
+		cfHandles[i] = NewNativeColumnFamilyHandle(c, cfDescriptors[i].Name)
+	}
+
+	return &OptimisticTransactionDB{
+		name: name,
+		c:    db,
+		opts: opts,
+	}, cfHandles, nil
+}
+
+// Name returns the name of the database.
+func (db *OptimisticTransactionDB) Name() string {
+	return db.name
+}
+
+// TransactionBegin begins a new transaction
+// with the WriteOptions and OptimisticTransactionOptions given.
+func (db *OptimisticTransactionDB) TransactionBegin(
+	opts *WriteOptions,
+	transactionOpts *OptimisticTransactionOptions,
+	oldTransaction *Transaction,
+) *Transaction {
+	if oldTransaction != nil {
+		return NewNativeTransaction(C.rocksdb_optimistictransaction_begin(
+			db.c,
+			opts.c,
+			transactionOpts.c,
+			oldTransaction.c,
+		))
+	}
+
+	return NewNativeTransaction(C.rocksdb_optimistictransaction_begin(
+		db.c, opts.c, transactionOpts.c, nil))
+}
+
+// GetBaseDb returns the underlying database, which can be used for
+// non-transactional reads and writes. The returned DB must be released with
+// CloseBaseDb and not with DB.Close, which would close the database itself.
+func (db *OptimisticTransactionDB) GetBaseDb() *DB {
+	return &DB{
+		c:    C.rocksdb_optimistictransactiondb_get_base_db(db.c),
+		name: db.name,
+		opts: db.opts,
+	}
+}
+
+// CloseBaseDb releases a DB obtained through GetBaseDb. The database stays
+// open until the OptimisticTransactionDB itself is closed.
+func (db *OptimisticTransactionDB) CloseBaseDb(base *DB) {
+	C.rocksdb_optimistictransactiondb_close_base_db(base.c)
+	base.c = nil
+}
+
+// Close closes the database.
+func (db *OptimisticTransactionDB) Close() {
+	C.rocksdb_optimistictransactiondb_close(db.c)
+	db.c = nil
+}
@@ -0,0 +1,168 @@
+package gorocksdb
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestOpenOptimisticTransactionDb(t *testing.T) {
+	db := newTestOptimisticTransactionDB(t, "TestOpenOptimisticTransactionDb", nil)
+	defer db.Close()
+}
+
+func TestOptimisticTransactionDBCRUD(t *testing.T) {
+	db := newTestOptimisticTransactionDB(t, "TestOptimisticTransactionDBCRUD", nil)
+	defer db.Close()
+
+	var (
+		givenKey  = []byte("hello")
+		givenVal1 = []byte("world1")
+		givenVal2 = []byte("world2")
+		wo        = NewDefaultWriteOptions()
+		ro        = NewDefaultReadOptions()
+		to        = NewDefaultOptimisticTransactionOptions()
+	)
+
+	base := db.GetBaseDb()
+	defer db.CloseBaseDb(base)
+
+	// create
+	txn := db.TransactionBegin(wo, to, nil)
+	defer txn.Destroy()
+	ensure.Nil(t, txn.Put(givenKey, givenVal1))
+	v1, err := txn.Get(ro, givenKey)
+	defer v1.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1.Data(), givenVal1)
+
+	// not visible outside of the transaction before commit
+	v2, err := base.Get(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v2.Data() == nil)
+
+	ensure.Nil(t, txn.Commit())
+	v3, err := base.Get(ro, givenKey)
+	defer v3.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v3.Data(), givenVal1)
+
+	// reuse the transaction object for an update
+	txn = db.TransactionBegin(wo, to, txn)
+	ensure.Nil(t, txn.Put(givenKey, givenVal2))
+	ensure.Nil(t, txn.Commit())
+	v4, err := base.Get(ro, givenKey)
+	defer v4.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v4.Data(), givenVal2)
+
+	// delete
+	txn2 := db.TransactionBegin(wo, to, nil)
+	defer txn2.Destroy()
+	ensure.Nil(t, txn2.Delete(givenKey))
+	ensure.Nil(t, txn2.Commit())
+	v5, err := base.Get(ro, givenKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v5.Data() == nil)
+}
+
+func TestOptimisticTransactionDBConflict(t *testing.T) {
+	db := newTestOptimisticTransactionDB(t, "TestOptimisticTransactionDBConflict", nil)
+	defer db.Close()
+
+	var (
+		givenKey = []byte("hello")
+		wo       = NewDefaultWriteOptions()
+		ro       = NewDefaultReadOptions()
+		to       = NewDefaultOptimisticTransactionOptions()
+	)
+	to.SetSetSnapshot(true)
+
+	txn1 := db.TransactionBegin(wo, to, nil)
+	defer txn1.Destroy()
+	txn2 := db.TransactionBegin(wo, to, nil)
+	defer txn2.Destroy()
+
+	ensure.Nil(t, txn1.Put(givenKey, []byte("txn1")))
+	ensure.Nil(t, txn2.Put(givenKey, []byte("txn2")))
+
+	ensure.Nil(t, txn1.Commit())
+	err := txn2.Commit()
+	ensure.NotNil(t, err)
+	ensure.True(t, errors.Is(err, ErrTransactionConflict))
+	ensure.Nil(t, txn2.Rollback())
+
+	base := db.GetBaseDb()
+	defer db.CloseBaseDb(base)
+	v, err := base.Get(ro, givenKey)
+	defer v.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v.Data(), []byte("txn1"))
+}
+
+func TestOptimisticTransactionDBColumnFamilies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksoptimistictransactiondb-TestOptimisticTransactionDBColumnFamilies")
+	ensure.Nil(t, err)
+
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetCreateIfMissing(true)
+	cfDescriptors := []*ColumnFamilyDescriptor{
+		&ColumnFamilyDescriptor{
+			Name:    "default",
+			Options: opts,
+		},
+		&ColumnFamilyDescriptor{
+			Name:    "guide",
+			Options: opts,
+		},
+	}
+
+	db, cfh, err := OpenOptimisticTransactionDbColumnFamilies(opts, dir, cfDescriptors)
+	ensure.Nil(t, err)
+	defer db.Close()
+	ensure.DeepEqual(t, len(cfh), 2)
+	defer cfh[0].Destroy()
+	defer cfh[1].Destroy()
+
+	var (
+		givenKey = []byte("hello")
+		givenVal = []byte("world")
+		wo       = NewDefaultWriteOptions()
+		ro       = NewDefaultReadOptions()
+		to       = NewDefaultOptimisticTransactionOptions()
+	)
+
+	txn := db.TransactionBegin(wo, to, nil)
+	defer txn.Destroy()
+	ensure.Nil(t, txn.PutCF(cfh[1], givenKey, givenVal))
+	ensure.Nil(t, txn.Commit())
+
+	base := db.GetBaseDb()
+	defer db.CloseBaseDb(base)
+	v1, err := base.GetCF(ro, cfh[1], givenKey)
+	defer v1.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1.Data(), givenVal)
+
+	v2, err := base.GetCF(ro, cfh[0], givenKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v2.Data() == nil)
+}
+
+func newTestOptimisticTransactionDB(t *testing.T, name string, applyOpts func(opts *Options)) *OptimisticTransactionDB {
+	dir, err := ioutil.TempDir("", "gorocksoptimistictransactiondb-"+name)
+	ensure.Nil(t, err)
+
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+	if applyOpts != nil {
+		applyOpts(opts)
+	}
+	db, err := OpenOptimisticTransactionDb(opts, dir)
+	ensure.Nil(t, err)
+
+	return db
+}
@@ -3,7 +3,10 @@ package gorocksdb
 // #include "rocksdb/c.h"
 // #include "gorocksdb.h"
 import "C"
-import "unsafe"
+import (
+	"errors"
+	"unsafe"
+)
 
 // CompressionType specifies the block compression.
 // DB contents are stored in a set of blocks, each of which holds a
@@ -55,6 +58,9 @@ const (
 	WarnInfoLogLevel  = InfoLogLevel(2)
 	ErrorInfoLogLevel = InfoLogLevel(3)
 	FatalInfoLogLevel = InfoLogLevel(4)
+	// HeaderInfoLogLevel is used for the header of the log, which is
+	// written at open regardless of the info log level.
+	HeaderInfoLogLevel = InfoLogLevel(5)
 )
 
 // Options represent all of the available options when opening a database with Open.
@@ -123,6 +129,24 @@ func (opts *Options) SetMergeOperator(value MergeOperator) {
 	C.rocksdb_options_set_merge_operator(opts.c, opts.cmo)
 }
 
+// AddEventListener adds a listener which will be notified about flushes,
+// compactions, write stalls, background errors and table files. It can be
+// called multiple times to add multiple listeners.
+// Default: no listeners
+func (opts *Options) AddEventListener(value EventListener) {
+	idx := registerEventListener(value)
+	C.gorocksdb_options_add_eventlistener(opts.c, C.uintptr_t(idx))
+}
+
+// AddTablePropertiesCollectorFactory adds a factory of collectors which
+// record custom properties into each table file. It can be called multiple
+// times to add multiple factories.
+// Default: no factories
+func (opts *Options) AddTablePropertiesCollectorFactory(value TablePropertiesCollectorFactory) {
+	idx := registerTablePropertiesCollectorFactory(value)
+	C.gorocksdb_options_add_table_properties_collector_factory(opts.c, C.uintptr_t(idx))
+}
+
 // A single CompactionFilter instance to call into during compaction.
 // Allows an application to modify/delete a key-value during background
 // compaction.
@@ -239,6 +263,16 @@ func (opts *Options) SetEnv(value *Env) {
 // Default: InfoInfoLogLevel
 func (opts *Options) SetInfoLogLevel(value InfoLogLevel) {
 	C.rocksdb_options_set_info_log_level(opts.c, C.int(value))
+	C.gorocksdb_options_sync_info_logger_level(opts.c)
+}
+
+// SetInfoLogger sets a logger which receives the info log messages of
+// RocksDB instead of the LOG file in the database or SetDbLogDir directory.
+// Messages below the level set with SetInfoLogLevel are dropped.
+// Default: nil, RocksDB writes to the LOG file
+func (opts *Options) SetInfoLogger(value Logger) {
+	idx := registerLogger(value)
+	C.gorocksdb_options_set_info_logger(opts.c, C.uintptr_t(idx))
 }
 
 // IncreaseParallelism sets the parallelism.
@@ -1109,6 +1143,29 @@ func (opts *Options) EnableStatistics() {
 	C.rocksdb_options_enable_statistics(opts.c)
 }
 
+// GetStatisticsString returns the statistics collected since EnableStatistics
+// was called, in the text format of RocksDB. It returns an empty string if
+// statistics are not enabled.
+func (opts *Options) GetStatisticsString() string {
+	cValue := C.rocksdb_options_statistics_get_string(opts.c)
+	if cValue == nil {
+		return ""
+	}
+	defer C.free(unsafe.Pointer(cValue))
+	return C.GoString(cValue)
+}
+
+// GetStatistics returns the statistics collected since EnableStatistics was
+// called, parsed into ticker counts and histograms. It returns an error if
+// statistics are not enabled.
+func (opts *Options) GetStatistics() (*Statistics, error) {
+	stats := opts.GetStatisticsString()
+	if stats == "" {
+		return nil, errors.New("statistics are not enabled")
+	}
+	return ParseStatistics(stats)
+}
+
 // PrepareForBulkLoad prepare the DB for bulk loading.
 //
 // All data will be in level 0 without any automatic compaction.
@@ -0,0 +1,120 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "gorocksdb.h"
+import "C"
+import "unsafe"
+
+// BackupEngineOptions represent all of the available options when opening a
+// backup engine with OpenBackupEngineWithOptions.
+type BackupEngineOptions struct {
+	c *C.gorocksdb_backup_engine_options_t
+}
+
+// NewDefaultBackupEngineOptions creates a default BackupEngineOptions object
+// for backups stored in backupDir.
+func NewDefaultBackupEngineOptions(backupDir string) *BackupEngineOptions {
+	cBackupDir := C.CString(backupDir)
+	defer C.free(unsafe.Pointer(cBackupDir))
+	return NewNativeBackupEngineOptions(C.gorocksdb_backup_engine_options_create(cBackupDir))
+}
+
+// NewNativeBackupEngineOptions creates a BackupEngineOptions object.
+func NewNativeBackupEngineOptions(c *C.gorocksdb_backup_engine_options_t) *BackupEngineOptions {
+	return &BackupEngineOptions{c}
+}
+
+// SetShareTableFiles specifies if table files are shared between backups.
+// If false, each backup is a complete copy of the database.
+// Default: true
+func (opts *BackupEngineOptions) SetShareTableFiles(value bool) {
+	C.gorocksdb_backup_engine_options_set_share_table_files(opts.c, boolToChar(value))
+}
+
+// SetShareFilesWithChecksum specifies if shared table files are named by
+// their checksum and size. This allows to share table files between backups
+// of different databases using the same backup directory. Only used if
+// share table files is enabled.
+// Default: false (true since RocksDB 6.12)
+func (opts *BackupEngineOptions) SetShareFilesWithChecksum(value bool) {
+	C.gorocksdb_backup_engine_options_set_share_files_with_checksum(opts.c, boolToChar(value))
+}
+
+// SetSync specifies if the backup files are synced to the disk.
+// If false, a backup may be corrupted on a machine crash or a power failure,
+// but it is faster to take.
+// Default: true
+func (opts *BackupEngineOptions) SetSync(value bool) {
+	C.gorocksdb_backup_engine_options_set_sync(opts.c, boolToChar(value))
+}
+
+// SetDestroyOldData specifies if all existing backups are deleted when the
+// backup engine is opened.
+// Default: false
+func (opts *BackupEngineOptions) SetDestroyOldData(value bool) {
+	C.gorocksdb_backup_engine_options_set_destroy_old_data(opts.c, boolToChar(value))
+}
+
+// SetBackupLogFiles specifies if the write ahead log files are backed up.
+// If false, the memtables are always flushed before a backup, and the backup
+// is not consistent with the writes that were not flushed.
+// Default: true
+func (opts *BackupEngineOptions) SetBackupLogFiles(value bool) {
+	C.gorocksdb_backup_engine_options_set_backup_log_files(opts.c, boolToChar(value))
+}
+
+// SetBackupRateLimit sets the maximum number of bytes per second written
+// while taking a backup. It is ignored if a backup rate limiter is set.
+// Default: 0 (unlimited)
+func (opts *BackupEngineOptions) SetBackupRateLimit(bytesPerSec uint64) {
+	C.gorocksdb_backup_engine_options_set_backup_rate_limit(opts.c, C.uint64_t(bytesPerSec))
+}
+
+// SetBackupRateLimiter sets the rate limiter used while taking a backup.
+// It can be shared with the database to bound the total IO of both.
+// Default: nil
+func (opts *BackupEngineOptions) SetBackupRateLimiter(rateLimiter *RateLimiter) {
+	C.gorocksdb_backup_engine_options_set_backup_rate_limiter(opts.c, rateLimiter.c)
+}
+
+// SetRestoreRateLimit sets the maximum number of bytes per second written
+// while restoring a backup. It is ignored if a restore rate limiter is set.
+// Default: 0 (unlimited)
+func (opts *BackupEngineOptions) SetRestoreRateLimit(bytesPerSec uint64) {
+	C.gorocksdb_backup_engine_options_set_restore_rate_limit(opts.c, C.uint64_t(bytesPerSec))
+}
+
+// SetRestoreRateLimiter sets the rate limiter used while restoring a backup.
+// Default: nil
+func (opts *BackupEngineOptions) SetRestoreRateLimiter(rateLimiter *RateLimiter) {
+	C.gorocksdb_backup_engine_options_set_restore_rate_limiter(opts.c, rateLimiter.c)
+}
+
+// SetMaxBackgroundOperations sets the number of threads used to copy the
+// files while taking and restoring backups.
+// Default: 1
+func (opts *BackupEngineOptions) SetMaxBackgroundOperations(value int) {
+	C.gorocksdb_backup_engine_options_set_max_background_operations(opts.c, C.int(value))
+}
+
+// SetCallbackTriggerIntervalSize sets the number of bytes copied between
+// calls of the progress callback. RocksDB also uses it to check whether a
+// backup should be stopped.
+// Default: 4MB
+func (opts *BackupEngineOptions) SetCallbackTriggerIntervalSize(size uint64) {
+	C.gorocksdb_backup_engine_options_set_callback_trigger_interval_size(opts.c, C.uint64_t(size))
+}
+
+// SetMaxValidBackupsToOpen sets the number of latest backups which are
+// opened and checked when the backup engine is opened. The older backups
+// can only be deleted.
+// Default: unlimited
+func (opts *BackupEngineOptions) SetMaxValidBackupsToOpen(value int) {
+	C.gorocksdb_backup_engine_options_set_max_valid_backups_to_open(opts.c, C.int(value))
+}
+
+// Destroy deallocates the BackupEngineOptions object.
+func (opts *BackupEngineOptions) Destroy() {
+	C.gorocksdb_backup_engine_options_destroy(opts.c)
+	opts.c = nil
+}
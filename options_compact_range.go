@@ -0,0 +1,80 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+
+// BottommostLevelCompaction specifies how a manual compaction treats the
+// bottommost level.
+type BottommostLevelCompaction uint
+
+// Bottommost level compaction policies.
+const (
+	// BottommostLevelCompactionSkip skips the bottommost level.
+	BottommostLevelCompactionSkip = BottommostLevelCompaction(C.gorocksdb_bottommost_level_compaction_skip)
+	// BottommostLevelCompactionIfHaveCompactionFilter compacts the bottommost
+	// level only if the column family has a compaction filter.
+	BottommostLevelCompactionIfHaveCompactionFilter = BottommostLevelCompaction(C.gorocksdb_bottommost_level_compaction_if_have_compaction_filter)
+	// BottommostLevelCompactionForce always compacts the bottommost level.
+	BottommostLevelCompactionForce = BottommostLevelCompaction(C.gorocksdb_bottommost_level_compaction_force)
+	// BottommostLevelCompactionForceOptimized always compacts the bottommost
+	// level, but skips the files created by this compaction.
+	BottommostLevelCompactionForceOptimized = BottommostLevelCompaction(C.gorocksdb_bottommost_level_compaction_force_optimized)
+)
+
+// CompactRangeOptions represent all of the available options for a manual
+// compaction of a range of keys.
+type CompactRangeOptions struct {
+	c *C.rocksdb_compactoptions_t
+}
+
+// NewDefaultCompactRangeOptions creates a default CompactRangeOptions object.
+func NewDefaultCompactRangeOptions() *CompactRangeOptions {
+	return NewNativeCompactRangeOptions(C.rocksdb_compactoptions_create())
+}
+
+// NewNativeCompactRangeOptions creates a CompactRangeOptions object.
+func NewNativeCompactRangeOptions(c *C.rocksdb_compactoptions_t) *CompactRangeOptions {
+	return &CompactRangeOptions{c}
+}
+
+// SetExclusiveManualCompaction specifies if no other compaction may run in
+// parallel to the manual compaction.
+// Default: true
+func (opts *CompactRangeOptions) SetExclusiveManualCompaction(value bool) {
+	C.rocksdb_compactoptions_set_exclusive_manual_compaction(opts.c, boolToChar(value))
+}
+
+// SetChangeLevel specifies if the compacted files are moved to the minimum
+// level capable of holding the data, or to the target level if one is set.
+// Default: false
+func (opts *CompactRangeOptions) SetChangeLevel(value bool) {
+	C.rocksdb_compactoptions_set_change_level(opts.c, boolToChar(value))
+}
+
+// SetTargetLevel sets the level the compacted files are moved to if
+// change level is enabled. A negative level moves the files to the minimum
+// level capable of holding the data.
+// Default: -1
+func (opts *CompactRangeOptions) SetTargetLevel(value int) {
+	C.rocksdb_compactoptions_set_target_level(opts.c, C.int(value))
+}
+
+// SetBottommostLevelCompaction sets the policy for the bottommost level.
+// Default: BottommostLevelCompactionIfHaveCompactionFilter
+func (opts *CompactRangeOptions) SetBottommostLevelCompaction(value BottommostLevelCompaction) {
+	C.rocksdb_compactoptions_set_bottommost_level_compaction(opts.c, C.uchar(value))
+}
+
+// SetTargetPathID sets the index of the db path, see Options.SetDBPaths,
+// the compacted files are written to.
+// Default: 0
+func (opts *CompactRangeOptions) SetTargetPathID(value uint32) {
+	C.gorocksdb_compactoptions_set_target_path_id(opts.c, C.uint32_t(value))
+}
+
+// Destroy deallocates the CompactRangeOptions object.
+func (opts *CompactRangeOptions) Destroy() {
+	C.rocksdb_compactoptions_destroy(opts.c)
+	opts.c = nil
+}
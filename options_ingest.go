@@ -1,6 +1,7 @@
 package gorocksdb
 
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 
 // IngestExternalFileOptions represents available options when ingesting external files.
@@ -58,6 +59,30 @@ func (opts *IngestExternalFileOptions) SetIngestionBehind(flag bool) {
 	C.rocksdb_ingestexternalfileoptions_set_ingest_behind(opts.c, boolToChar(flag))
 }
 
+// SetFailIfNotBottommostLevel sets fail_if_not_bottommost_level. If set to
+// true, the ingestion fails if a file can not be ingested into the
+// bottommost level.
+// Default to false.
+func (opts *IngestExternalFileOptions) SetFailIfNotBottommostLevel(flag bool) {
+	C.gorocksdb_ingestexternalfileoptions_set_fail_if_not_bottommost_level(opts.c, boolToChar(flag))
+}
+
+// SetVerifyChecksumsBeforeIngest sets verify_checksums_before_ingest. If set
+// to true, the checksums of all blocks of the files are verified before they
+// are ingested.
+// Default to false.
+func (opts *IngestExternalFileOptions) SetVerifyChecksumsBeforeIngest(flag bool) {
+	C.gorocksdb_ingestexternalfileoptions_set_verify_checksums_before_ingest(opts.c, boolToChar(flag))
+}
+
+// SetWriteGlobalSeqno sets write_global_seqno. If set to true, the global
+// sequence number assigned to a file is written into the file, which
+// modifies the file. If set to false, it is only stored in the MANIFEST.
+// The default depends on the RocksDB version.
+func (opts *IngestExternalFileOptions) SetWriteGlobalSeqno(flag bool) {
+	C.gorocksdb_ingestexternalfileoptions_set_write_global_seqno(opts.c, boolToChar(flag))
+}
+
 // Destroy deallocates the IngestExternalFileOptions object.
 func (opts *IngestExternalFileOptions) Destroy() {
 	C.rocksdb_ingestexternalfileoptions_destroy(opts.c)
@@ -24,6 +24,9 @@ const (
 // database.
 type ReadOptions struct {
 	c *C.rocksdb_readoptions_t
+
+	// settings made through the setters, by option, to clone the options
+	settings map[string]func(c *C.rocksdb_readoptions_t)
 }
 
 // NewDefaultReadOptions creates a default ReadOptions object.
@@ -33,7 +36,7 @@ func NewDefaultReadOptions() *ReadOptions {
 
 // NewNativeReadOptions creates a ReadOptions object.
 func NewNativeReadOptions(c *C.rocksdb_readoptions_t) *ReadOptions {
-	return &ReadOptions{c}
+	return &ReadOptions{c: c}
 }
 
 // UnsafeGetReadOptions returns the underlying c read options object.
@@ -45,7 +48,9 @@ func (opts *ReadOptions) UnsafeGetReadOptions() unsafe.Pointer {
 // verified against corresponding checksums.
 // Default: false
 func (opts *ReadOptions) SetVerifyChecksums(value bool) {
-	C.rocksdb_readoptions_set_verify_checksums(opts.c, boolToChar(value))
+	opts.set("verify_checksums", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_verify_checksums(c, boolToChar(value))
+	})
 }
 
 // SetFillCache specify whether the "data block"/"index block"/"filter block"
@@ -53,7 +58,9 @@ func (opts *ReadOptions) SetVerifyChecksums(value bool) {
 // Callers may wish to set this field to false for bulk scans.
 // Default: true
 func (opts *ReadOptions) SetFillCache(value bool) {
-	C.rocksdb_readoptions_set_fill_cache(opts.c, boolToChar(value))
+	opts.set("fill_cache", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_fill_cache(c, boolToChar(value))
+	})
 }
 
 // SetSnapshot sets the snapshot which should be used for the read.
@@ -61,7 +68,9 @@ func (opts *ReadOptions) SetFillCache(value bool) {
 // not have been released.
 // Default: nil
 func (opts *ReadOptions) SetSnapshot(snap *Snapshot) {
-	C.rocksdb_readoptions_set_snapshot(opts.c, snap.c)
+	opts.set("snapshot", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_snapshot(c, snap.c)
+	})
 }
 
 // SetReadTier specify if this read request should process data that ALREADY
@@ -69,7 +78,9 @@ func (opts *ReadOptions) SetSnapshot(snap *Snapshot) {
 // found at the specified cache, then Status::Incomplete is returned.
 // Default: ReadAllTier
 func (opts *ReadOptions) SetReadTier(value ReadTier) {
-	C.rocksdb_readoptions_set_read_tier(opts.c, C.int(value))
+	opts.set("read_tier", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_read_tier(c, C.int(value))
+	})
 }
 
 // SetTailing specify if to create a tailing iterator.
@@ -79,7 +90,9 @@ func (opts *ReadOptions) SetReadTier(value ReadTier) {
 // that were inserted into the database after the creation of the iterator.
 // Default: false
 func (opts *ReadOptions) SetTailing(value bool) {
-	C.rocksdb_readoptions_set_tailing(opts.c, boolToChar(value))
+	opts.set("tailing", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_tailing(c, boolToChar(value))
+	})
 }
 
 // SetIterateUpperBound specifies "iterate_upper_bound", which defines
@@ -93,9 +106,11 @@ func (opts *ReadOptions) SetTailing(value bool) {
 // implemented.
 // Default: nullptr
 func (opts *ReadOptions) SetIterateUpperBound(key []byte) {
-	cKey := byteToChar(key)
-	cKeyLen := C.size_t(len(key))
-	C.rocksdb_readoptions_set_iterate_upper_bound(opts.c, cKey, cKeyLen)
+	opts.set("iterate_upper_bound", func(c *C.rocksdb_readoptions_t) {
+		cKey := byteToChar(key)
+		cKeyLen := C.size_t(len(key))
+		C.rocksdb_readoptions_set_iterate_upper_bound(c, cKey, cKeyLen)
+	})
 }
 
 // SetIterateLowerBound specifies `iterate_lower_bound`, which defines
@@ -110,9 +125,11 @@ func (opts *ReadOptions) SetIterateUpperBound(key []byte) {
 //
 // Default: nullptr
 func (opts *ReadOptions) SetIterateLowerBound(key []byte) {
-	cKey := byteToChar(key)
-	cKeyLen := C.size_t(len(key))
-	C.rocksdb_readoptions_set_iterate_lower_bound(opts.c, cKey, cKeyLen)
+	opts.set("iterate_lower_bound", func(c *C.rocksdb_readoptions_t) {
+		cKey := byteToChar(key)
+		cKeyLen := C.size_t(len(key))
+		C.rocksdb_readoptions_set_iterate_lower_bound(c, cKey, cKeyLen)
+	})
 }
 
 // SetPinData specifies the value of "pin_data". If true, it keeps the blocks
@@ -123,7 +140,9 @@ func (opts *ReadOptions) SetIterateLowerBound(key []byte) {
 // return 1.
 // Default: false
 func (opts *ReadOptions) SetPinData(value bool) {
-	C.rocksdb_readoptions_set_pin_data(opts.c, boolToChar(value))
+	opts.set("pin_data", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_pin_data(c, boolToChar(value))
+	})
 }
 
 // SetTotalOrderSeek ...
@@ -134,7 +153,9 @@ func (opts *ReadOptions) SetPinData(value bool) {
 // block based table. It provides a way to read existing data after
 // changing implementation of prefix extractor.
 func (opts *ReadOptions) SetTotalOrderSeek(value bool) {
-	C.rocksdb_readoptions_set_total_order_seek(opts.c, boolToChar(value))
+	opts.set("total_order_seek", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_total_order_seek(c, boolToChar(value))
+	})
 }
 
 // SetReadaheadSize specifies the value of "readahead_size".
@@ -143,7 +164,9 @@ func (opts *ReadOptions) SetTotalOrderSeek(value bool) {
 // improve the performance of forward iteration on spinning disks.
 // Default: 0
 func (opts *ReadOptions) SetReadaheadSize(value uint64) {
-	C.rocksdb_readoptions_set_readahead_size(opts.c, C.size_t(value))
+	opts.set("readahead_size", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_readahead_size(c, C.size_t(value))
+	})
 }
 
 // SetPrefixSameAsStart ...
@@ -154,7 +177,9 @@ func (opts *ReadOptions) SetReadaheadSize(value uint64) {
 // but in both directions.
 // Default: false
 func (opts *ReadOptions) SetPrefixSameAsStart(value bool) {
-	C.rocksdb_readoptions_set_prefix_same_as_start(opts.c, boolToChar(value))
+	opts.set("prefix_same_as_start", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_prefix_same_as_start(c, boolToChar(value))
+	})
 }
 
 // SetBackgroundPurgeOnIteratorCleanup ...
@@ -163,7 +188,9 @@ func (opts *ReadOptions) SetPrefixSameAsStart(value bool) {
 // in background.
 // Default: false
 func (opts *ReadOptions) SetBackgroundPurgeOnIteratorCleanup(value bool) {
-	C.rocksdb_readoptions_set_background_purge_on_iterator_cleanup(opts.c, boolToChar(value))
+	opts.set("background_purge_on_iterator_cleanup", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_background_purge_on_iterator_cleanup(c, boolToChar(value))
+	})
 }
 
 // SetIgnoreRangeDeletions ...
@@ -172,7 +199,9 @@ func (opts *ReadOptions) SetBackgroundPurgeOnIteratorCleanup(value bool) {
 // read performance in DBs with many range deletions.
 // Default: false
 func (opts *ReadOptions) SetIgnoreRangeDeletions(value bool) {
-	C.rocksdb_readoptions_set_ignore_range_deletions(opts.c, boolToChar(value))
+	opts.set("ignore_range_deletions", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_ignore_range_deletions(c, boolToChar(value))
+	})
 }
 
 // SetMaxSkippableInternalKeys ...
@@ -181,7 +210,28 @@ func (opts *ReadOptions) SetIgnoreRangeDeletions(value bool) {
 // never fail a request as incomplete, even on skipping too many keys.
 // Default: 0
 func (opts *ReadOptions) SetMaxSkippableInternalKeys(value uint64) {
-	C.rocksdb_readoptions_set_max_skippable_internal_keys(opts.c, C.uint64_t(value))
+	opts.set("max_skippable_internal_keys", func(c *C.rocksdb_readoptions_t) {
+		C.rocksdb_readoptions_set_max_skippable_internal_keys(c, C.uint64_t(value))
+	})
+}
+
+func (opts *ReadOptions) set(name string, apply func(c *C.rocksdb_readoptions_t)) {
+	apply(opts.c)
+	if opts.settings == nil {
+		opts.settings = make(map[string]func(c *C.rocksdb_readoptions_t))
+	}
+	opts.settings[name] = apply
+}
+
+// clone returns new ReadOptions with the settings made through the setters
+// of opts. Settings made on native ReadOptions through the C API are not
+// copied.
+func (opts *ReadOptions) clone() *ReadOptions {
+	clone := NewDefaultReadOptions()
+	for name, apply := range opts.settings {
+		clone.set(name, apply)
+	}
+	return clone
 }
 
 // Destroy deallocates the ReadOptions object.
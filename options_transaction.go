@@ -7,6 +7,11 @@ import "C"
 // a transaction on the database.
 type TransactionOptions struct {
 	c *C.rocksdb_transaction_options_t
+
+	// settings made through the setters, by option, to clone the options
+	settings    map[string]func(c *C.rocksdb_transaction_options_t)
+	lockTimeout int64
+	expiration  int64
 }
 
 // NewDefaultTransactionOptions creates a default TransactionOptions object.
@@ -14,22 +19,28 @@ func NewDefaultTransactionOptions() *TransactionOptions {
 	return NewNativeTransactionOptions(C.rocksdb_transaction_options_create())
 }
 
-// NewNativeTransactionOptions creates a TransactionOptions object.
+// NewNativeTransactionOptions creates a TransactionOptions object. The
+// getters return the defaults for settings which were not made through the
+// setters.
 func NewNativeTransactionOptions(c *C.rocksdb_transaction_options_t) *TransactionOptions {
-	return &TransactionOptions{c}
+	return &TransactionOptions{c: c, lockTimeout: -1, expiration: -1}
 }
 
 // SetSetSnapshot to true is the same as calling
 // Transaction::SetSnapshot().
 func (opts *TransactionOptions) SetSetSnapshot(value bool) {
-	C.rocksdb_transaction_options_set_set_snapshot(opts.c, boolToChar(value))
+	opts.set("set_snapshot", func(c *C.rocksdb_transaction_options_t) {
+		C.rocksdb_transaction_options_set_set_snapshot(c, boolToChar(value))
+	})
 }
 
 // SetDeadlockDetect to true means that before acquiring locks, this transaction will
 // check if doing so will cause a deadlock. If so, it will return with
 // Status::Busy.  The user should retry their transaction.
 func (opts *TransactionOptions) SetDeadlockDetect(value bool) {
-	C.rocksdb_transaction_options_set_deadlock_detect(opts.c, boolToChar(value))
+	opts.set("deadlock_detect", func(c *C.rocksdb_transaction_options_t) {
+		C.rocksdb_transaction_options_set_deadlock_detect(c, boolToChar(value))
+	})
 }
 
 // SetLockTimeout positive, specifies the wait timeout in milliseconds when
@@ -37,7 +48,16 @@ func (opts *TransactionOptions) SetDeadlockDetect(value bool) {
 // If 0, no waiting is done if a lock cannot instantly be acquired.
 // If negative, TransactionDBOptions::transaction_lock_timeout will be used
 func (opts *TransactionOptions) SetLockTimeout(lockTimeout int64) {
-	C.rocksdb_transaction_options_set_lock_timeout(opts.c, C.int64_t(lockTimeout))
+	opts.set("lock_timeout", func(c *C.rocksdb_transaction_options_t) {
+		C.rocksdb_transaction_options_set_lock_timeout(c, C.int64_t(lockTimeout))
+	})
+	opts.lockTimeout = lockTimeout
+}
+
+// GetLockTimeout returns the lock timeout in milliseconds, see
+// SetLockTimeout.
+func (opts *TransactionOptions) GetLockTimeout() int64 {
+	return opts.lockTimeout
 }
 
 // SetExpiration sets the Expiration duration in milliseconds.
@@ -46,17 +66,50 @@ func (opts *TransactionOptions) SetLockTimeout(lockTimeout int64) {
 // will never relinquish any locks it holds.  This could prevent keys from
 // being written by other writers.
 func (opts *TransactionOptions) SetExpiration(expiration int64) {
-	C.rocksdb_transaction_options_set_expiration(opts.c, C.int64_t(expiration))
+	opts.set("expiration", func(c *C.rocksdb_transaction_options_t) {
+		C.rocksdb_transaction_options_set_expiration(c, C.int64_t(expiration))
+	})
+	opts.expiration = expiration
+}
+
+// GetExpiration returns the expiration duration in milliseconds, see
+// SetExpiration.
+func (opts *TransactionOptions) GetExpiration() int64 {
+	return opts.expiration
 }
 
 // SetDeadlockDetectDepth sets the number of traversals to make during deadlock detection.
 func (opts *TransactionOptions) SetDeadlockDetectDepth(depth int64) {
-	C.rocksdb_transaction_options_set_deadlock_detect_depth(opts.c, C.int64_t(depth))
+	opts.set("deadlock_detect_depth", func(c *C.rocksdb_transaction_options_t) {
+		C.rocksdb_transaction_options_set_deadlock_detect_depth(c, C.int64_t(depth))
+	})
 }
 
 // SetMaxWriteBatchSize sets the maximum number of bytes used for the write batch. 0 means no limit.
 func (opts *TransactionOptions) SetMaxWriteBatchSize(size uint64) {
-	C.rocksdb_transaction_options_set_max_write_batch_size(opts.c, C.size_t(size))
+	opts.set("max_write_batch_size", func(c *C.rocksdb_transaction_options_t) {
+		C.rocksdb_transaction_options_set_max_write_batch_size(c, C.size_t(size))
+	})
+}
+
+func (opts *TransactionOptions) set(name string, apply func(c *C.rocksdb_transaction_options_t)) {
+	apply(opts.c)
+	if opts.settings == nil {
+		opts.settings = make(map[string]func(c *C.rocksdb_transaction_options_t))
+	}
+	opts.settings[name] = apply
+}
+
+// clone returns new TransactionOptions with the settings made through the
+// setters of opts.
+func (opts *TransactionOptions) clone() *TransactionOptions {
+	clone := NewDefaultTransactionOptions()
+	for name, apply := range opts.settings {
+		clone.set(name, apply)
+	}
+	clone.lockTimeout = opts.lockTimeout
+	clone.expiration = opts.expiration
+	return clone
 }
 
 // Destroy deallocates the TransactionOptions object.
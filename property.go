@@ -0,0 +1,93 @@
+package gorocksdb
+
+import "strconv"
+
+// Property is the name of a database property, see DB.GetProperty,
+// DB.GetIntProperty and DB.GetMapProperty.
+type Property string
+
+// Properties with a string value.
+const (
+	// PropertyStats returns a multi-line string with the general statistics
+	// of the database, combining PropertyCFStats and PropertyDBStats.
+	PropertyStats = Property("rocksdb.stats")
+	// PropertySSTables returns a multi-line string summarizing the SST files.
+	PropertySSTables = Property("rocksdb.sstables")
+	// PropertyCFStatsNoFileHistogram returns a multi-line string with the
+	// general column family statistics without the file histogram.
+	PropertyCFStatsNoFileHistogram = Property("rocksdb.cfstats-no-file-histogram")
+	// PropertyCFFileHistogram returns a multi-line string with the read
+	// latency histogram per level.
+	PropertyCFFileHistogram = Property("rocksdb.cf-file-histogram")
+	// PropertyLevelStats returns a multi-line string with the number of files
+	// per level and the total size of each level in MB.
+	PropertyLevelStats = Property("rocksdb.levelstats")
+	// PropertyOptionsStatistics returns a multi-line string with the
+	// statistics collected when statistics are enabled.
+	PropertyOptionsStatistics = Property("rocksdb.options-statistics")
+)
+
+// Properties with a string value and a map value.
+const (
+	// PropertyCFStats returns the general column family statistics. Read it
+	// with GetMapProperty to get the values per level.
+	PropertyCFStats = Property("rocksdb.cfstats")
+	// PropertyDBStats returns the general database statistics.
+	PropertyDBStats = Property("rocksdb.dbstats")
+	// PropertyBlockCacheEntryStats returns statistics about the entries in
+	// the block cache.
+	PropertyBlockCacheEntryStats = Property("rocksdb.block-cache-entry-stats")
+	// PropertyAggregatedTableProperties returns the table properties
+	// aggregated over all SST files.
+	PropertyAggregatedTableProperties = Property("rocksdb.aggregated-table-properties")
+)
+
+// Properties with an integer value.
+const (
+	PropertyNumImmutableMemTable           = Property("rocksdb.num-immutable-mem-table")
+	PropertyNumImmutableMemTableFlushed    = Property("rocksdb.num-immutable-mem-table-flushed")
+	PropertyMemTableFlushPending           = Property("rocksdb.mem-table-flush-pending")
+	PropertyNumRunningFlushes              = Property("rocksdb.num-running-flushes")
+	PropertyCompactionPending              = Property("rocksdb.compaction-pending")
+	PropertyNumRunningCompactions          = Property("rocksdb.num-running-compactions")
+	PropertyBackgroundErrors               = Property("rocksdb.background-errors")
+	PropertyCurSizeActiveMemTable          = Property("rocksdb.cur-size-active-mem-table")
+	PropertyCurSizeAllMemTables            = Property("rocksdb.cur-size-all-mem-tables")
+	PropertySizeAllMemTables               = Property("rocksdb.size-all-mem-tables")
+	PropertyNumEntriesActiveMemTable       = Property("rocksdb.num-entries-active-mem-table")
+	PropertyNumEntriesImmMemTables         = Property("rocksdb.num-entries-imm-mem-tables")
+	PropertyNumDeletesActiveMemTable       = Property("rocksdb.num-deletes-active-mem-table")
+	PropertyNumDeletesImmMemTables         = Property("rocksdb.num-deletes-imm-mem-tables")
+	PropertyEstimateNumKeys                = Property("rocksdb.estimate-num-keys")
+	PropertyEstimateTableReadersMem        = Property("rocksdb.estimate-table-readers-mem")
+	PropertyIsFileDeletionsEnabled         = Property("rocksdb.is-file-deletions-enabled")
+	PropertyNumSnapshots                   = Property("rocksdb.num-snapshots")
+	PropertyOldestSnapshotTime             = Property("rocksdb.oldest-snapshot-time")
+	PropertyNumLiveVersions                = Property("rocksdb.num-live-versions")
+	PropertyCurrentSuperVersionNumber      = Property("rocksdb.current-super-version-number")
+	PropertyEstimateLiveDataSize           = Property("rocksdb.estimate-live-data-size")
+	PropertyMinLogNumberToKeep             = Property("rocksdb.min-log-number-to-keep")
+	PropertyMinObsoleteSstNumberToKeep     = Property("rocksdb.min-obsolete-sst-number-to-keep")
+	PropertyTotalSstFilesSize              = Property("rocksdb.total-sst-files-size")
+	PropertyLiveSstFilesSize               = Property("rocksdb.live-sst-files-size")
+	PropertyBaseLevel                      = Property("rocksdb.base-level")
+	PropertyEstimatePendingCompactionBytes = Property("rocksdb.estimate-pending-compaction-bytes")
+	PropertyActualDelayedWriteRate         = Property("rocksdb.actual-delayed-write-rate")
+	PropertyIsWriteStopped                 = Property("rocksdb.is-write-stopped")
+	PropertyEstimateOldestKeyTime          = Property("rocksdb.estimate-oldest-key-time")
+	PropertyBlockCacheCapacity             = Property("rocksdb.block-cache-capacity")
+	PropertyBlockCacheUsage                = Property("rocksdb.block-cache-usage")
+	PropertyBlockCachePinnedUsage          = Property("rocksdb.block-cache-pinned-usage")
+)
+
+// PropertyNumFilesAtLevel returns the property holding the number of files
+// at the given level.
+func PropertyNumFilesAtLevel(level int) Property {
+	return Property("rocksdb.num-files-at-level" + strconv.Itoa(level))
+}
+
+// PropertyCompressionRatioAtLevel returns the property holding the
+// compression ratio of the data at the given level.
+func PropertyCompressionRatioAtLevel(level int) Property {
+	return Property("rocksdb.compression-ratio-at-level" + strconv.Itoa(level))
+}
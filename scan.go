@@ -0,0 +1,241 @@
+//go:build go1.23
+// +build go1.23
+
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+import "C"
+
+import (
+	"bytes"
+	"iter"
+)
+
+// Scan is a scan over a range of keys, created by the Scan, ScanPrefix and
+// ScanReverse methods of DB, TransactionDB, Transaction and SnapshotView.
+//
+//	scan := db.Scan(ro, []byte("a"), []byte("b"))
+//	for key, value := range scan.All() {
+//		...
+//	}
+//	if err := scan.Err(); err != nil {
+//		...
+//	}
+//
+// Each loop over the scan creates a new iterator, which is closed when the
+// loop ends, also on break. The iterator uses a copy of the settings made
+// through the setters of the ReadOptions, or default ReadOptions if they are
+// nil, with the bounds of the scan, so the ReadOptions are not changed. The
+// bounds of the scan replace the iterate bounds of the ReadOptions on the
+// sides the scan is bounded.
+//
+// A Scan must not be iterated by several loops at the same time, since Err
+// reports the error of the last loop; create a Scan per goroutine instead.
+type Scan struct {
+	newIterator func(opts *ReadOptions) *Iterator
+	opts        *ReadOptions
+	snapshot    *Snapshot
+	lower       []byte
+	upper       []byte
+	reverse     bool
+	err         error
+}
+
+func newScan(newIterator func(opts *ReadOptions) *Iterator, opts *ReadOptions, lower, upper []byte, reverse bool) *Scan {
+	return &Scan{
+		newIterator: newIterator,
+		opts:        opts,
+		lower:       lower,
+		upper:       upper,
+		reverse:     reverse,
+	}
+}
+
+// All returns an iterator over copies of the keys and values of the scan.
+func (s *Scan) All() iter.Seq2[[]byte, []byte] {
+	return s.seq(false)
+}
+
+// Borrowed returns an iterator over the keys and values of the scan without
+// copying them. The keys and values are only valid until the next iteration
+// of the loop.
+func (s *Scan) Borrowed() iter.Seq2[[]byte, []byte] {
+	return s.seq(true)
+}
+
+// Err returns the error of the last loop over the scan, if any.
+func (s *Scan) Err() error {
+	return s.err
+}
+
+func (s *Scan) seq(borrow bool) iter.Seq2[[]byte, []byte] {
+	return func(yield func(key, value []byte) bool) {
+		s.err = nil
+		var opts *ReadOptions
+		if s.opts != nil {
+			opts = s.opts.clone()
+		} else {
+			opts = NewDefaultReadOptions()
+		}
+		defer opts.Destroy()
+
+		// the read options keep pointers to the bounds, so they are copied
+		// to C memory which stays valid until the iterator is closed
+		cLower := C.CBytes(s.lower)
+		defer C.free(cLower)
+		cUpper := C.CBytes(s.upper)
+		defer C.free(cUpper)
+		if len(s.lower) > 0 {
+			C.rocksdb_readoptions_set_iterate_lower_bound(opts.c, (*C.char)(cLower), C.size_t(len(s.lower)))
+		}
+		if len(s.upper) > 0 {
+			C.rocksdb_readoptions_set_iterate_upper_bound(opts.c, (*C.char)(cUpper), C.size_t(len(s.upper)))
+		}
+		if s.snapshot != nil {
+			C.rocksdb_readoptions_set_snapshot(opts.c, s.snapshot.c)
+		}
+
+		it := s.newIterator(opts)
+		defer it.Close()
+		if s.reverse {
+			it.SeekToLast()
+		} else {
+			it.SeekToFirst()
+		}
+		for ; it.Valid(); s.step(it) {
+			key, value := it.Key().Data(), it.Value().Data()
+			if !borrow {
+				key, value = bytes.Clone(key), bytes.Clone(value)
+			}
+			if !yield(key, value) {
+				break
+			}
+		}
+		s.err = it.Err()
+	}
+}
+
+func (s *Scan) step(it *Iterator) {
+	if s.reverse {
+		it.Prev()
+	} else {
+		it.Next()
+	}
+}
+
+// prefixUpperBound returns the smallest key greater than all keys starting
+// with prefix in bytewise order, or nil if there is none.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			upper := bytes.Clone(prefix[:i+1])
+			upper[i]++
+			return upper
+		}
+	}
+	return nil
+}
+
+// Scan returns a scan over the keys of the database in the range
+// [lower, upper). A nil lower or upper leaves the range unbounded on that
+// side.
+func (db *DB) Scan(opts *ReadOptions, lower, upper []byte) *Scan {
+	return newScan(db.NewIterator, opts, lower, upper, false)
+}
+
+// ScanPrefix returns a scan over the keys of the database starting with
+// prefix. The database must use the bytewise comparator.
+func (db *DB) ScanPrefix(opts *ReadOptions, prefix []byte) *Scan {
+	return newScan(db.NewIterator, opts, prefix, prefixUpperBound(prefix), false)
+}
+
+// ScanReverse returns a scan over the keys of the database in the range
+// [lower, upper) in reverse order, see Scan.
+func (db *DB) ScanReverse(opts *ReadOptions, lower, upper []byte) *Scan {
+	return newScan(db.NewIterator, opts, lower, upper, true)
+}
+
+// Scan returns a scan over the keys of the database in the range
+// [lower, upper). A nil lower or upper leaves the range unbounded on that
+// side.
+func (db *TransactionDB) Scan(opts *ReadOptions, lower, upper []byte) *Scan {
+	return newScan(db.NewIterator, opts, lower, upper, false)
+}
+
+// ScanPrefix returns a scan over the keys of the database starting with
+// prefix. The database must use the bytewise comparator.
+func (db *TransactionDB) ScanPrefix(opts *ReadOptions, prefix []byte) *Scan {
+	return newScan(db.NewIterator, opts, prefix, prefixUpperBound(prefix), false)
+}
+
+// ScanReverse returns a scan over the keys of the database in the range
+// [lower, upper) in reverse order, see Scan.
+func (db *TransactionDB) ScanReverse(opts *ReadOptions, lower, upper []byte) *Scan {
+	return newScan(db.NewIterator, opts, lower, upper, true)
+}
+
+// Scan returns a scan over the keys of the database, including the writes
+// of the transaction, in the range [lower, upper). A nil lower or upper
+// leaves the range unbounded on that side.
+func (transaction *Transaction) Scan(opts *ReadOptions, lower, upper []byte) *Scan {
+	return newScan(transaction.NewIterator, opts, lower, upper, false)
+}
+
+// ScanPrefix returns a scan over the keys of the database, including the
+// writes of the transaction, starting with prefix. The database must use
+// the bytewise comparator.
+func (transaction *Transaction) ScanPrefix(opts *ReadOptions, prefix []byte) *Scan {
+	return newScan(transaction.NewIterator, opts, prefix, prefixUpperBound(prefix), false)
+}
+
+// ScanReverse returns a scan over the keys of the database, including the
+// writes of the transaction, in the range [lower, upper) in reverse order,
+// see Scan.
+func (transaction *Transaction) ScanReverse(opts *ReadOptions, lower, upper []byte) *Scan {
+	return newScan(transaction.NewIterator, opts, lower, upper, true)
+}
+
+// SnapshotView scans a database as of a snapshot. The snapshot replaces the
+// snapshot of the ReadOptions of a scan.
+type SnapshotView struct {
+	snapshot    *Snapshot
+	newIterator func(opts *ReadOptions) *Iterator
+}
+
+// NewSnapshotView returns a view of the database as of the snapshot, which
+// must not be released while the view is used.
+func (db *DB) NewSnapshotView(snapshot *Snapshot) *SnapshotView {
+	return &SnapshotView{snapshot: snapshot, newIterator: db.NewIterator}
+}
+
+// NewSnapshotView returns a view of the database as of the snapshot, which
+// must not be released while the view is used.
+func (db *TransactionDB) NewSnapshotView(snapshot *Snapshot) *SnapshotView {
+	return &SnapshotView{snapshot: snapshot, newIterator: db.NewIterator}
+}
+
+func (v *SnapshotView) newScan(opts *ReadOptions, lower, upper []byte, reverse bool) *Scan {
+	s := newScan(v.newIterator, opts, lower, upper, reverse)
+	s.snapshot = v.snapshot
+	return s
+}
+
+// Scan returns a scan over the keys of the snapshot in the range
+// [lower, upper). A nil lower or upper leaves the range unbounded on that
+// side.
+func (v *SnapshotView) Scan(opts *ReadOptions, lower, upper []byte) *Scan {
+	return v.newScan(opts, lower, upper, false)
+}
+
+// ScanPrefix returns a scan over the keys of the snapshot starting with
+// prefix. The database must use the bytewise comparator.
+func (v *SnapshotView) ScanPrefix(opts *ReadOptions, prefix []byte) *Scan {
+	return v.newScan(opts, prefix, prefixUpperBound(prefix), false)
+}
+
+// ScanReverse returns a scan over the keys of the snapshot in the range
+// [lower, upper) in reverse order, see Scan.
+func (v *SnapshotView) ScanReverse(opts *ReadOptions, lower, upper []byte) *Scan {
+	return v.newScan(opts, lower, upper, true)
+}
@@ -0,0 +1,102 @@
+//go:build go1.23
+// +build go1.23
+
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func collectScan(t *testing.T, scan *Scan) []string {
+	var kvs []string
+	for key, value := range scan.All() {
+		kvs = append(kvs, string(key)+"="+string(value))
+	}
+	ensure.Nil(t, scan.Err())
+	return kvs
+}
+
+func TestDBScan(t *testing.T) {
+	db := newTestDB(t, "TestDBScan", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	for _, key := range []string{"a1", "b1", "b2", "b3", "c1"} {
+		ensure.Nil(t, db.Put(wo, []byte(key), []byte("v"+key)))
+	}
+
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	ensure.DeepEqual(t, collectScan(t, db.Scan(ro, []byte("b1"), []byte("b3"))), []string{"b1=vb1", "b2=vb2"})
+	ensure.DeepEqual(t, collectScan(t, db.Scan(nil, nil, []byte("b"))), []string{"a1=va1"})
+	ensure.DeepEqual(t, collectScan(t, db.ScanPrefix(ro, []byte("b"))), []string{"b1=vb1", "b2=vb2", "b3=vb3"})
+	ensure.DeepEqual(t, collectScan(t, db.ScanReverse(ro, []byte("b"), nil)), []string{"c1=vc1", "b3=vb3", "b2=vb2", "b1=vb1"})
+
+	// the bounds are cleared after a scan
+	ensure.DeepEqual(t, len(collectScan(t, db.Scan(ro, nil, nil))), 5)
+
+	// break early
+	var keys []string
+	scan := db.Scan(ro, nil, nil)
+	for key := range scan.Borrowed() {
+		keys = append(keys, string(key))
+		if len(keys) == 2 {
+			break
+		}
+	}
+	ensure.Nil(t, scan.Err())
+	ensure.DeepEqual(t, keys, []string{"a1", "b1"})
+
+	// scan a snapshot
+	snapshot := db.NewSnapshot()
+	defer db.ReleaseSnapshot(snapshot)
+	ensure.Nil(t, db.Put(wo, []byte("b4"), []byte("vb4")))
+	view := db.NewSnapshotView(snapshot)
+	ensure.DeepEqual(t, collectScan(t, view.ScanPrefix(ro, []byte("b"))), []string{"b1=vb1", "b2=vb2", "b3=vb3"})
+	ensure.DeepEqual(t, collectScan(t, db.ScanPrefix(ro, []byte("b"))), []string{"b1=vb1", "b2=vb2", "b3=vb3", "b4=vb4"})
+
+	// the snapshot and bounds of the read options are kept
+	snapshotRo := NewDefaultReadOptions()
+	defer snapshotRo.Destroy()
+	snapshotRo.SetSnapshot(snapshot)
+	snapshotRo.SetIterateUpperBound([]byte("c"))
+	ensure.DeepEqual(t, collectScan(t, db.Scan(snapshotRo, []byte("b3"), nil)), []string{"b3=vb3"})
+	latest := db.NewSnapshot()
+	defer db.ReleaseSnapshot(latest)
+	ensure.DeepEqual(t, collectScan(t, db.NewSnapshotView(latest).Scan(snapshotRo, []byte("b3"), nil)), []string{"b3=vb3", "b4=vb4"})
+	iter := db.NewIterator(snapshotRo)
+	defer iter.Close()
+	var keys2 []string
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		keys2 = append(keys2, string(iter.Key().Data()))
+	}
+	ensure.Nil(t, iter.Err())
+	ensure.DeepEqual(t, keys2, []string{"a1", "b1", "b2", "b3"})
+}
+
+func TestTransactionScan(t *testing.T) {
+	db := newTestTransactionDB(t, "TestTransactionScan", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("a1"), []byte("va1")))
+	ensure.Nil(t, db.Put(wo, []byte("a3"), []byte("va3")))
+
+	txn := db.TransactionBegin(wo, NewDefaultTransactionOptions(), nil)
+	defer txn.Destroy()
+	ensure.Nil(t, txn.Put([]byte("a2"), []byte("va2")))
+
+	ensure.DeepEqual(t, collectScan(t, txn.ScanPrefix(nil, []byte("a"))), []string{"a1=va1", "a2=va2", "a3=va3"})
+	ensure.DeepEqual(t, collectScan(t, db.ScanReverse(nil, nil, nil)), []string{"a3=va3", "a1=va1"})
+	ensure.Nil(t, txn.Commit())
+	ensure.DeepEqual(t, collectScan(t, db.Scan(nil, []byte("a2"), nil)), []string{"a2=va2", "a3=va3"})
+}
+
+func TestPrefixUpperBound(t *testing.T) {
+	ensure.DeepEqual(t, prefixUpperBound([]byte("ab")), []byte("ac"))
+	ensure.DeepEqual(t, prefixUpperBound([]byte{'a', 0xff}), []byte("b"))
+	ensure.True(t, prefixUpperBound([]byte{0xff, 0xff}) == nil)
+	ensure.True(t, prefixUpperBound(nil) == nil)
+}
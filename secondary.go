@@ -0,0 +1,116 @@
+package gorocksdb
+
+import (
+	"sync"
+	"time"
+)
+
+// CatchUpStatus is the result of a catch-up of a secondary instance with its
+// primary.
+type CatchUpStatus struct {
+	// Time is the time at which the catch-up finished.
+	Time time.Time
+	// Sequence is the latest sequence number of the secondary after the
+	// catch-up.
+	Sequence uint64
+	// Replayed is the number of sequence numbers the catch-up replayed,
+	// that is how far the secondary was behind the primary before the
+	// catch-up.
+	Replayed uint64
+	// Err is the error of the catch-up, if any. Sequence and Replayed are
+	// not updated then.
+	Err error
+}
+
+// SecondaryFollower makes a secondary instance, opened with
+// OpenDbAsSecondary, catch up with its primary periodically in a background
+// goroutine.
+type SecondaryFollower struct {
+	db        *DB
+	onCatchUp func(CatchUpStatus)
+
+	// catchUpMu serializes catch-ups, mu guards the published status and
+	// stop channel
+	catchUpMu sync.Mutex
+	mu        sync.Mutex
+	status    CatchUpStatus
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// StartSecondaryFollower starts catching up db with its primary every
+// interval until Stop is called. If onCatchUp is not nil, it is called with
+// the status of each catch-up from the background goroutine.
+func StartSecondaryFollower(db *DB, interval time.Duration, onCatchUp func(CatchUpStatus)) *SecondaryFollower {
+	f := &SecondaryFollower{
+		db:        db,
+		onCatchUp: onCatchUp,
+		status:    CatchUpStatus{Sequence: db.GetLatestSequenceNumber()},
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go f.run(interval, f.stop)
+	return f
+}
+
+func (f *SecondaryFollower) run(interval time.Duration, stop chan struct{}) {
+	defer close(f.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			status := f.CatchUp()
+			if f.onCatchUp != nil {
+				f.onCatchUp(status)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// CatchUp makes the secondary catch up with its primary immediately and
+// returns the status of the catch-up. Catch-ups, including the background
+// ones, run one at a time.
+func (f *SecondaryFollower) CatchUp() CatchUpStatus {
+	// the catch-up can take long, so mu is only held to publish the status
+	f.catchUpMu.Lock()
+	defer f.catchUpMu.Unlock()
+	before := f.db.GetLatestSequenceNumber()
+	err := f.db.TryCatchUpWithPrimary()
+	after := f.db.GetLatestSequenceNumber()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	status := CatchUpStatus{Sequence: f.status.Sequence, Replayed: f.status.Replayed, Err: err}
+	if err == nil {
+		status.Sequence = after
+		status.Replayed = after - before
+	}
+	status.Time = time.Now()
+	f.status = status
+	return status
+}
+
+// Status returns the status of the latest catch-up.
+func (f *SecondaryFollower) Status() CatchUpStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.status
+}
+
+// Stop stops catching up and waits until the background goroutine has
+// finished. The database is not closed.
+func (f *SecondaryFollower) Stop() {
+	f.mu.Lock()
+	stop := f.stop
+	f.stop = nil
+	f.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-f.done
+}
@@ -0,0 +1,125 @@
+package gorocksdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestOpenDbAsSecondary(t *testing.T) {
+	primary := newTestDB(t, "TestOpenDbAsSecondary", nil)
+	defer primary.Close()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, primary.Put(wo, []byte("key1"), []byte("value1")))
+
+	secondaryPath, err := ioutil.TempDir("", "gorocksdb-TestOpenDbAsSecondary-secondary")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(secondaryPath)
+
+	opts := NewDefaultOptions()
+	opts.SetMaxOpenFiles(-1)
+	secondary, err := OpenDbAsSecondary(opts, primary.Name(), secondaryPath)
+	ensure.Nil(t, err)
+	defer secondary.Close()
+
+	ro := NewDefaultReadOptions()
+	value, err := secondary.GetBytes(ro, []byte("key1"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, value, []byte("value1"))
+
+	// the secondary sees new writes only after catching up
+	ensure.Nil(t, primary.Put(wo, []byte("key2"), []byte("value2")))
+	value, err = secondary.GetBytes(ro, []byte("key2"))
+	ensure.Nil(t, err)
+	ensure.True(t, value == nil)
+
+	ensure.Nil(t, secondary.TryCatchUpWithPrimary())
+	value, err = secondary.GetBytes(ro, []byte("key2"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, value, []byte("value2"))
+	ensure.DeepEqual(t, secondary.GetLatestSequenceNumber(), primary.GetLatestSequenceNumber())
+
+	// a primary can not catch up
+	ensure.NotNil(t, primary.TryCatchUpWithPrimary())
+}
+
+func TestOpenDbAsSecondaryColumnFamilies(t *testing.T) {
+	primary, cfh, cleanup := newTestDBCF(t, "TestOpenDbAsSecondaryColumnFamilies")
+	defer cleanup()
+
+	ensure.Nil(t, primary.PutCF(NewDefaultWriteOptions(), cfh[1], []byte("key"), []byte("value")))
+
+	secondaryPath, err := ioutil.TempDir("", "gorocksdb-TestOpenDbAsSecondaryColumnFamilies-secondary")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(secondaryPath)
+
+	opts := NewDefaultOptions()
+	opts.SetMaxOpenFiles(-1)
+	secondary, handles, err := OpenDbAsSecondaryColumnFamilies(opts, primary.Name(), secondaryPath, []*ColumnFamilyDescriptor{
+		{Name: "default", Options: opts},
+		{Name: "guide", Options: opts},
+	})
+	ensure.Nil(t, err)
+	defer secondary.Close()
+	defer func() {
+		for _, h := range handles {
+			h.Destroy()
+		}
+	}()
+
+	value, err := secondary.GetCF(NewDefaultReadOptions(), handles[1], []byte("key"))
+	ensure.Nil(t, err)
+	defer value.Free()
+	ensure.DeepEqual(t, value.Data(), []byte("value"))
+}
+
+func TestSecondaryFollower(t *testing.T) {
+	primary := newTestDB(t, "TestSecondaryFollower", nil)
+	defer primary.Close()
+
+	secondaryPath, err := ioutil.TempDir("", "gorocksdb-TestSecondaryFollower-secondary")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(secondaryPath)
+
+	opts := NewDefaultOptions()
+	opts.SetMaxOpenFiles(-1)
+	secondary, err := OpenDbAsSecondary(opts, primary.Name(), secondaryPath)
+	ensure.Nil(t, err)
+	defer secondary.Close()
+
+	statuses := make(chan CatchUpStatus, 100)
+	follower := StartSecondaryFollower(secondary, 10*time.Millisecond, func(status CatchUpStatus) {
+		select {
+		case statuses <- status:
+		default:
+		}
+	})
+	defer follower.Stop()
+
+	wo := NewDefaultWriteOptions()
+	for i := 0; i < 3; i++ {
+		ensure.Nil(t, primary.Put(wo, []byte("key"), []byte("value")))
+	}
+	want := primary.GetLatestSequenceNumber()
+
+	var replayed uint64
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case status := <-statuses:
+			ensure.Nil(t, status.Err)
+			replayed += status.Replayed
+			if status.Sequence == want {
+				ensure.DeepEqual(t, replayed, uint64(3))
+				ensure.DeepEqual(t, follower.Status().Sequence, want)
+				return
+			}
+		case <-timeout:
+			t.Fatal("secondary did not catch up")
+		}
+	}
+}
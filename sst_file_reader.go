@@ -0,0 +1,105 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "gorocksdb.h"
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// errSSTFileReaderNotOpen is returned by the methods of an SSTFileReader
+// reading the file before the file was opened successfully.
+var errSSTFileReaderNotOpen = errors.New("sst file reader is not open")
+
+// SSTFileReader is used to read sst files, for example files created with an
+// SSTFileWriter before they are ingested.
+type SSTFileReader struct {
+	c      *C.gorocksdb_sstfilereader_t
+	opened bool
+}
+
+// NewSSTFileReader creates an SSTFileReader object. The options must match
+// the options the file was written with, for example the comparator.
+func NewSSTFileReader(opts *Options) *SSTFileReader {
+	return &SSTFileReader{c: C.gorocksdb_sstfilereader_create(opts.c)}
+}
+
+// Open opens the file located at "path" for reading. The other methods
+// return an error until a file was opened successfully.
+func (r *SSTFileReader) Open(path string) error {
+	var (
+		cErr  *C.char
+		cPath = C.CString(path)
+	)
+	defer C.free(unsafe.Pointer(cPath))
+	r.opened = false
+	C.gorocksdb_sstfilereader_open(r.c, cPath, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
+	}
+	r.opened = true
+	return nil
+}
+
+// NewIterator returns an Iterator over the entries of the file. The
+// iterator must be closed before the reader is destroyed.
+func (r *SSTFileReader) NewIterator(opts *ReadOptions) (*Iterator, error) {
+	if !r.opened {
+		return nil, errSSTFileReaderNotOpen
+	}
+	cIter := C.gorocksdb_sstfilereader_new_iterator(r.c, opts.c)
+	return NewNativeIterator(unsafe.Pointer(cIter)), nil
+}
+
+// GetTableProperties returns the table properties of the file.
+func (r *SSTFileReader) GetTableProperties() (*TableProperties, error) {
+	if !r.opened {
+		return nil, errSSTFileReaderNotOpen
+	}
+	cProps := C.gorocksdb_sstfilereader_get_table_properties(r.c)
+	defer C.gorocksdb_table_properties_destroy(cProps)
+	return newTableProperties(cProps), nil
+}
+
+// GetKeyRange returns the smallest and the largest key of the file. Both are
+// nil if the file is empty.
+func (r *SSTFileReader) GetKeyRange() (smallest, largest []byte, err error) {
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	iter, err := r.NewIterator(ro)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer iter.Close()
+
+	iter.SeekToFirst()
+	if iter.Valid() {
+		smallest = append([]byte(nil), iter.Key().Data()...)
+		iter.SeekToLast()
+		largest = append([]byte(nil), iter.Key().Data()...)
+	}
+	return smallest, largest, iter.Err()
+}
+
+// VerifyChecksum verifies the checksums of all blocks of the file.
+func (r *SSTFileReader) VerifyChecksum() error {
+	if !r.opened {
+		return errSSTFileReaderNotOpen
+	}
+	var cErr *C.char
+	C.gorocksdb_sstfilereader_verify_checksum(r.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// Destroy destroys the SSTFileReader object.
+func (r *SSTFileReader) Destroy() {
+	C.gorocksdb_sstfilereader_destroy(r.c)
+	r.c = nil
+}
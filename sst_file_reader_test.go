@@ -0,0 +1,75 @@
+package gorocksdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestSSTFileReader(t *testing.T) {
+	filePath := newTestSSTFile(t, "aaa", "aaaValue", "bbb", "bbbValue", "ccc", "cccValue")
+	defer os.Remove(filePath)
+
+	r := NewSSTFileReader(NewDefaultOptions())
+	defer r.Destroy()
+	ensure.Nil(t, r.Open(filePath))
+	ensure.Nil(t, r.VerifyChecksum())
+
+	props, err := r.GetTableProperties()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, props.NumEntries, uint64(3))
+	ensure.DeepEqual(t, props.NumDeletions, uint64(0))
+	ensure.DeepEqual(t, props.ComparatorName, "leveldb.BytewiseComparator")
+	ensure.NotDeepEqual(t, props.CompressionName, "")
+
+	smallest, largest, err := r.GetKeyRange()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, smallest, []byte("aaa"))
+	ensure.DeepEqual(t, largest, []byte("ccc"))
+
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	iter, err := r.NewIterator(ro)
+	ensure.Nil(t, err)
+	defer iter.Close()
+	var keys, values []string
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key().Data()))
+		values = append(values, string(iter.Value().Data()))
+	}
+	ensure.Nil(t, iter.Err())
+	ensure.DeepEqual(t, keys, []string{"aaa", "bbb", "ccc"})
+	ensure.DeepEqual(t, values, []string{"aaaValue", "bbbValue", "cccValue"})
+}
+
+func TestSSTFileReaderCorruptFile(t *testing.T) {
+	filePath := newTestSSTFile(t, "aaa", "aaaValue")
+	defer os.Remove(filePath)
+
+	// flip a byte in the first data block
+	data, err := ioutil.ReadFile(filePath)
+	ensure.Nil(t, err)
+	data[0] ^= 0xff
+	ensure.Nil(t, ioutil.WriteFile(filePath, data, 0644))
+
+	r := NewSSTFileReader(NewDefaultOptions())
+	defer r.Destroy()
+	ensure.Nil(t, r.Open(filePath))
+	ensure.NotNil(t, r.VerifyChecksum())
+}
+
+func TestSSTFileReaderNotOpen(t *testing.T) {
+	r := NewSSTFileReader(NewDefaultOptions())
+	defer r.Destroy()
+	ensure.NotNil(t, r.Open("/nonexistent/file.sst"))
+
+	_, err := r.GetTableProperties()
+	ensure.DeepEqual(t, err, errSSTFileReaderNotOpen)
+	_, _, err = r.GetKeyRange()
+	ensure.DeepEqual(t, err, errSSTFileReaderNotOpen)
+	ensure.DeepEqual(t, r.VerifyChecksum(), errSSTFileReaderNotOpen)
+	_, err = r.NewIterator(NewDefaultReadOptions())
+	ensure.DeepEqual(t, err, errSSTFileReaderNotOpen)
+}
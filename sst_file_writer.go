@@ -4,10 +4,7 @@ package gorocksdb
 // #include "rocksdb/c.h"
 import "C"
 
-import (
-	"errors"
-	"unsafe"
-)
+import "unsafe"
 
 // SSTFileWriter is used to create sst files that can be added to database later.
 // All keys in files generated by SstFileWriter will have sequence number = 0.
@@ -31,7 +28,7 @@ func (w *SSTFileWriter) Open(path string) error {
 	C.rocksdb_sstfilewriter_open(w.c, cPath, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -45,7 +42,7 @@ func (w *SSTFileWriter) Add(key, value []byte) error {
 	C.rocksdb_sstfilewriter_add(w.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -59,7 +56,7 @@ func (w *SSTFileWriter) Put(key, value []byte) error {
 	C.rocksdb_sstfilewriter_put(w.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -75,7 +72,7 @@ func (w *SSTFileWriter) Merge(key, value []byte) error {
 	C.rocksdb_sstfilewriter_merge(w.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -90,7 +87,7 @@ func (w *SSTFileWriter) Delete(key []byte) error {
 	C.rocksdb_sstfilewriter_delete(w.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -101,7 +98,7 @@ func (w *SSTFileWriter) Finish() error {
 	C.rocksdb_sstfilewriter_finish(w.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
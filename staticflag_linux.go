@@ -2,5 +2,6 @@
 
 package gorocksdb
 
+// #cgo CXXFLAGS: -std=c++17
 // #cgo LDFLAGS: -l:librocksdb.a -l:libstdc++.a -l:libz.a -l:libbz2.a -l:libsnappy.a -lm
 import "C"
@@ -0,0 +1,162 @@
+package gorocksdb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ticker is the name of a statistics counter.
+type Ticker string
+
+// Tickers collected when statistics are enabled.
+const (
+	TickerBlockCacheMiss            = Ticker("rocksdb.block.cache.miss")
+	TickerBlockCacheHit             = Ticker("rocksdb.block.cache.hit")
+	TickerBlockCacheAdd             = Ticker("rocksdb.block.cache.add")
+	TickerBlockCacheAddFailures     = Ticker("rocksdb.block.cache.add.failures")
+	TickerBlockCacheIndexMiss       = Ticker("rocksdb.block.cache.index.miss")
+	TickerBlockCacheIndexHit        = Ticker("rocksdb.block.cache.index.hit")
+	TickerBlockCacheFilterMiss      = Ticker("rocksdb.block.cache.filter.miss")
+	TickerBlockCacheFilterHit       = Ticker("rocksdb.block.cache.filter.hit")
+	TickerBlockCacheDataMiss        = Ticker("rocksdb.block.cache.data.miss")
+	TickerBlockCacheDataHit         = Ticker("rocksdb.block.cache.data.hit")
+	TickerBlockCacheBytesRead       = Ticker("rocksdb.block.cache.bytes.read")
+	TickerBlockCacheBytesWrite      = Ticker("rocksdb.block.cache.bytes.write")
+	TickerBloomFilterUseful         = Ticker("rocksdb.bloom.filter.useful")
+	TickerMemtableHit               = Ticker("rocksdb.memtable.hit")
+	TickerMemtableMiss              = Ticker("rocksdb.memtable.miss")
+	TickerGetHitL0                  = Ticker("rocksdb.l0.hit")
+	TickerGetHitL1                  = Ticker("rocksdb.l1.hit")
+	TickerGetHitL2AndUp             = Ticker("rocksdb.l2andup.hit")
+	TickerNumberKeysWritten         = Ticker("rocksdb.number.keys.written")
+	TickerNumberKeysRead            = Ticker("rocksdb.number.keys.read")
+	TickerNumberKeysUpdated         = Ticker("rocksdb.number.keys.updated")
+	TickerBytesWritten              = Ticker("rocksdb.bytes.written")
+	TickerBytesRead                 = Ticker("rocksdb.bytes.read")
+	TickerNumberDBSeek              = Ticker("rocksdb.number.db.seek")
+	TickerNumberDBNext              = Ticker("rocksdb.number.db.next")
+	TickerNumberDBPrev              = Ticker("rocksdb.number.db.prev")
+	TickerIterBytesRead             = Ticker("rocksdb.db.iter.bytes.read")
+	TickerStallMicros               = Ticker("rocksdb.stall.micros")
+	TickerWALFileSynced             = Ticker("rocksdb.wal.synced")
+	TickerWALFileBytes              = Ticker("rocksdb.wal.bytes")
+	TickerCompactReadBytes          = Ticker("rocksdb.compact.read.bytes")
+	TickerCompactWriteBytes         = Ticker("rocksdb.compact.write.bytes")
+	TickerFlushWriteBytes           = Ticker("rocksdb.flush.write.bytes")
+	TickerCompactionKeyDropNewer    = Ticker("rocksdb.compaction.key.drop.new")
+	TickerCompactionKeyDropObsolete = Ticker("rocksdb.compaction.key.drop.obsolete")
+	TickerNoFileOpens               = Ticker("rocksdb.no.file.opens")
+	TickerNoFileErrors              = Ticker("rocksdb.no.file.errors")
+)
+
+// Histogram is the name of a statistics histogram.
+type Histogram string
+
+// Histograms collected when statistics are enabled.
+const (
+	HistogramDBGet            = Histogram("rocksdb.db.get.micros")
+	HistogramDBWrite          = Histogram("rocksdb.db.write.micros")
+	HistogramDBMultiGet       = Histogram("rocksdb.db.multiget.micros")
+	HistogramDBSeek           = Histogram("rocksdb.db.seek.micros")
+	HistogramDBFlush          = Histogram("rocksdb.db.flush.micros")
+	HistogramCompactionTime   = Histogram("rocksdb.compaction.times.micros")
+	HistogramWriteStall       = Histogram("rocksdb.db.write.stall")
+	HistogramWALFileSync      = Histogram("rocksdb.wal.file.sync.micros")
+	HistogramTableSync        = Histogram("rocksdb.table.sync.micros")
+	HistogramSSTRead          = Histogram("rocksdb.sst.read.micros")
+	HistogramBytesPerRead     = Histogram("rocksdb.bytes.per.read")
+	HistogramBytesPerWrite    = Histogram("rocksdb.bytes.per.write")
+	HistogramBytesPerMultiGet = Histogram("rocksdb.bytes.per.multiget")
+)
+
+// HistogramData holds the percentiles and totals of a histogram.
+type HistogramData struct {
+	P50   float64
+	P95   float64
+	P99   float64
+	P100  float64
+	Count uint64
+	Sum   uint64
+}
+
+// Statistics holds the ticker counts and histograms collected by RocksDB
+// when statistics are enabled with Options.EnableStatistics.
+type Statistics struct {
+	Tickers    map[Ticker]uint64
+	Histograms map[Histogram]HistogramData
+}
+
+// ParseStatistics parses statistics in the text format of RocksDB, as
+// returned by Options.GetStatisticsString. Every line holds either a ticker
+//
+//	rocksdb.block.cache.miss COUNT : 5
+//
+// or a histogram
+//
+//	rocksdb.db.get.micros P50 : 1.000000 P95 : 2.000000 P99 : 3.000000 P100 : 4.000000 COUNT : 5 SUM : 6
+func ParseStatistics(stats string) (*Statistics, error) {
+	s := &Statistics{
+		Tickers:    make(map[Ticker]uint64),
+		Histograms: make(map[Histogram]HistogramData),
+	}
+	for _, line := range strings.Split(stats, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// the name is followed by "KEY : VALUE" triples
+		if len(fields)%3 != 1 {
+			return nil, fmt.Errorf("malformed statistics line %q", line)
+		}
+		values := make(map[string]string, len(fields)/3)
+		for i := 1; i < len(fields); i += 3 {
+			if fields[i+1] != ":" {
+				return nil, fmt.Errorf("malformed statistics line %q", line)
+			}
+			values[fields[i]] = fields[i+2]
+		}
+
+		var err error
+		if len(values) == 1 {
+			s.Tickers[Ticker(fields[0])], err = parseStatisticsUint(values, "COUNT")
+		} else {
+			s.Histograms[Histogram(fields[0])], err = parseHistogramData(values)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("malformed statistics line %q: %v", line, err)
+		}
+	}
+	return s, nil
+}
+
+func parseHistogramData(values map[string]string) (HistogramData, error) {
+	var (
+		h   HistogramData
+		err error
+	)
+	for key, dst := range map[string]*float64{"P50": &h.P50, "P95": &h.P95, "P99": &h.P99, "P100": &h.P100} {
+		value, ok := values[key]
+		if !ok {
+			return h, fmt.Errorf("missing %s", key)
+		}
+		if *dst, err = strconv.ParseFloat(value, 64); err != nil {
+			return h, err
+		}
+	}
+	if h.Count, err = parseStatisticsUint(values, "COUNT"); err != nil {
+		return h, err
+	}
+	if h.Sum, err = parseStatisticsUint(values, "SUM"); err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+func parseStatisticsUint(values map[string]string, key string) (uint64, error) {
+	value, ok := values[key]
+	if !ok {
+		return 0, fmt.Errorf("missing %s", key)
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
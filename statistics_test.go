@@ -0,0 +1,61 @@
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestParseStatistics(t *testing.T) {
+	stats, err := ParseStatistics(`rocksdb.block.cache.miss COUNT : 5
+rocksdb.block.cache.hit COUNT : 12
+rocksdb.stall.micros COUNT : 0
+rocksdb.db.get.micros P50 : 1.500000 P95 : 3.000000 P99 : 7.250000 P100 : 9.000000 COUNT : 17 SUM : 42
+`)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, stats.Tickers, map[Ticker]uint64{
+		TickerBlockCacheMiss: 5,
+		TickerBlockCacheHit:  12,
+		TickerStallMicros:    0,
+	})
+	ensure.DeepEqual(t, stats.Histograms, map[Histogram]HistogramData{
+		HistogramDBGet: {P50: 1.5, P95: 3, P99: 7.25, P100: 9, Count: 17, Sum: 42},
+	})
+
+	_, err = ParseStatistics("rocksdb.block.cache.miss COUNT 5\n")
+	ensure.NotNil(t, err)
+	_, err = ParseStatistics("rocksdb.db.get.micros P50 : 1.0 COUNT : 1\n")
+	ensure.NotNil(t, err)
+}
+
+func TestDBStatistics(t *testing.T) {
+	var opts *Options
+	db := newTestDB(t, "TestDBStatistics", func(o *Options) {
+		o.EnableStatistics()
+		opts = o
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("hello"), []byte("world")))
+	v, err := db.Get(ro, []byte("hello"))
+	ensure.Nil(t, err)
+	v.Free()
+
+	stats, err := opts.GetStatistics()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, stats.Tickers[TickerNumberKeysWritten], uint64(1))
+	ensure.DeepEqual(t, stats.Tickers[TickerNumberKeysRead], uint64(1))
+	ensure.DeepEqual(t, stats.Histograms[HistogramDBGet].Count, uint64(1))
+}
+
+func TestStatisticsNotEnabled(t *testing.T) {
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+	ensure.DeepEqual(t, opts.GetStatisticsString(), "")
+	_, err := opts.GetStatistics()
+	ensure.NotNil(t, err)
+}
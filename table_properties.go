@@ -0,0 +1,106 @@
+package gorocksdb
+
+// #include "gorocksdb.h"
+import "C"
+
+// TableProperties are the properties of a table file.
+type TableProperties struct {
+	// DataSize is the total size of the data blocks.
+	DataSize uint64
+	// IndexSize is the size of the index block.
+	IndexSize uint64
+	// FilterSize is the size of the filter block.
+	FilterSize uint64
+	// RawKeySize is the total size of the keys before compression.
+	RawKeySize uint64
+	// RawValueSize is the total size of the values before compression.
+	RawValueSize uint64
+	// NumDataBlocks is the number of data blocks.
+	NumDataBlocks uint64
+	// NumEntries is the number of entries, including deletions and merge
+	// operands.
+	NumEntries        uint64
+	NumDeletions      uint64
+	NumMergeOperands  uint64
+	NumRangeDeletions uint64
+	FormatVersion     uint64
+	// CreationTime is the time, in seconds since the Unix epoch, of the
+	// oldest data in the file, or 0 if unknown.
+	CreationTime uint64
+	// OldestKeyTime is the time, in seconds since the Unix epoch, at which
+	// the oldest key of the file was written, or 0 if unknown.
+	OldestKeyTime uint64
+	// FileCreationTime is the time, in seconds since the Unix epoch, at which
+	// the file was created, or 0 if unknown.
+	FileCreationTime    uint64
+	ColumnFamilyName    string
+	ComparatorName      string
+	MergeOperatorName   string
+	PrefixExtractorName string
+	CompressionName     string
+	// UserCollectedProperties are the properties collected by the table
+	// properties collectors.
+	UserCollectedProperties map[string]string
+}
+
+// newTableProperties converts the table properties c, which must be
+// destroyed by the caller.
+func newTableProperties(c *C.gorocksdb_table_properties_t) *TableProperties {
+	var data C.gorocksdb_table_properties_data_t
+	C.gorocksdb_table_properties_get_data(c, &data)
+
+	props := &TableProperties{
+		DataSize:                uint64(data.data_size),
+		IndexSize:               uint64(data.index_size),
+		FilterSize:              uint64(data.filter_size),
+		RawKeySize:              uint64(data.raw_key_size),
+		RawValueSize:            uint64(data.raw_value_size),
+		NumDataBlocks:           uint64(data.num_data_blocks),
+		NumEntries:              uint64(data.num_entries),
+		NumDeletions:            uint64(data.num_deletions),
+		NumMergeOperands:        uint64(data.num_merge_operands),
+		NumRangeDeletions:       uint64(data.num_range_deletions),
+		FormatVersion:           uint64(data.format_version),
+		CreationTime:            uint64(data.creation_time),
+		OldestKeyTime:           uint64(data.oldest_key_time),
+		FileCreationTime:        uint64(data.file_creation_time),
+		ColumnFamilyName:        C.GoString(data.column_family_name),
+		ComparatorName:          C.GoString(data.comparator_name),
+		MergeOperatorName:       C.GoString(data.merge_operator_name),
+		PrefixExtractorName:     C.GoString(data.prefix_extractor_name),
+		CompressionName:         C.GoString(data.compression_name),
+		UserCollectedProperties: make(map[string]string, int(data.num_user_collected_properties)),
+	}
+
+	n := int(data.num_user_collected_properties)
+	if n == 0 {
+		return props
+	}
+	var (
+		cKeys      = make([]*C.char, n)
+		cKeyLens   = make([]C.size_t, n)
+		cValues    = make([]*C.char, n)
+		cValueLens = make([]C.size_t, n)
+	)
+	C.gorocksdb_table_properties_get_user_collected(c, &cKeys[0], &cKeyLens[0], &cValues[0], &cValueLens[0])
+	for i := 0; i < n; i++ {
+		key := C.GoStringN(cKeys[i], C.int(cKeyLens[i]))
+		props.UserCollectedProperties[key] = C.GoStringN(cValues[i], C.int(cValueLens[i]))
+	}
+	return props
+}
+
+// tablePropertiesCollection converts and destroys the collection c.
+func tablePropertiesCollection(c *C.gorocksdb_table_properties_collection_t) map[string]*TableProperties {
+	defer C.gorocksdb_table_properties_collection_destroy(c)
+
+	count := int(C.gorocksdb_table_properties_collection_count(c))
+	collection := make(map[string]*TableProperties, count)
+	for i := 0; i < count; i++ {
+		name := C.GoString(C.gorocksdb_table_properties_collection_name(c, C.int(i)))
+		cProps := C.gorocksdb_table_properties_collection_properties(c, C.int(i))
+		collection[name] = newTableProperties(cProps)
+		C.gorocksdb_table_properties_destroy(cProps)
+	}
+	return collection
+}
@@ -0,0 +1,144 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "gorocksdb.h"
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// EntryType is the type of an entry passed to a TablePropertiesCollector.
+type EntryType int
+
+// Entry types.
+const (
+	EntryPut           = EntryType(C.gorocksdb_entry_put)
+	EntryDelete        = EntryType(C.gorocksdb_entry_delete)
+	EntrySingleDelete  = EntryType(C.gorocksdb_entry_single_delete)
+	EntryMerge         = EntryType(C.gorocksdb_entry_merge)
+	EntryRangeDeletion = EntryType(C.gorocksdb_entry_range_deletion)
+	EntryBlobIndex     = EntryType(C.gorocksdb_entry_blob_index)
+	EntryOther         = EntryType(C.gorocksdb_entry_other)
+)
+
+// A TablePropertiesCollector collects custom properties of a table file
+// while it is written. The properties are stored in the file and returned in
+// TableProperties.UserCollectedProperties.
+//
+// The methods are called from the thread writing the file, for every entry,
+// so they should be fast.
+type TablePropertiesCollector interface {
+	// AddUserKey is called for each entry added to the file, in the order
+	// of the keys. fileSize is the current size of the file.
+	AddUserKey(key, value []byte, entryType EntryType, seq uint64, fileSize uint64) error
+
+	// Finish is called once all entries were added and returns the
+	// properties to store in the file.
+	Finish() (map[string]string, error)
+
+	// The name of the collector.
+	Name() string
+}
+
+// A TablePropertiesCollectorFactory creates a TablePropertiesCollector for
+// each table file written, see Options.AddTablePropertiesCollectorFactory.
+type TablePropertiesCollectorFactory interface {
+	// CreateTablePropertiesCollector returns a new collector for a file of
+	// the column family with the given id. It must not return nil.
+	CreateTablePropertiesCollector(columnFamilyID uint32) TablePropertiesCollector
+
+	// The name of the factory.
+	Name() string
+}
+
+// Hold references to table properties collector factories.
+var tablePropertiesCollectorFactories = NewCOWList()
+
+type tablePropertiesCollectorFactoryWrapper struct {
+	name    *C.char
+	factory TablePropertiesCollectorFactory
+}
+
+func registerTablePropertiesCollectorFactory(factory TablePropertiesCollectorFactory) int {
+	return tablePropertiesCollectorFactories.Append(tablePropertiesCollectorFactoryWrapper{C.CString(factory.Name()), factory})
+}
+
+// tablePropertiesCollectors holds the collectors of the files being written.
+// Unlike the factories, collectors are short-lived, so they are removed once
+// their file is written.
+var tablePropertiesCollectors = struct {
+	sync.RWMutex
+	next       uintptr
+	collectors map[uintptr]TablePropertiesCollector
+}{collectors: make(map[uintptr]TablePropertiesCollector)}
+
+func getTablePropertiesCollector(handle C.uintptr_t) TablePropertiesCollector {
+	tablePropertiesCollectors.RLock()
+	defer tablePropertiesCollectors.RUnlock()
+	return tablePropertiesCollectors.collectors[uintptr(handle)]
+}
+
+// tablePropertiesCollectorError returns err as C string allocated with malloc,
+// or nil.
+func tablePropertiesCollectorError(err error) *C.char {
+	if err == nil {
+		return nil
+	}
+	return C.CString(err.Error())
+}
+
+//export gorocksdb_tablepropertiescollectorfactory_create
+func gorocksdb_tablepropertiescollectorfactory_create(idx int, cfID C.uint32_t) C.uintptr_t {
+	factory := tablePropertiesCollectorFactories.Get(idx).(tablePropertiesCollectorFactoryWrapper).factory
+	collector := factory.CreateTablePropertiesCollector(uint32(cfID))
+
+	tablePropertiesCollectors.Lock()
+	defer tablePropertiesCollectors.Unlock()
+	tablePropertiesCollectors.next++
+	handle := tablePropertiesCollectors.next
+	tablePropertiesCollectors.collectors[handle] = collector
+	return C.uintptr_t(handle)
+}
+
+//export gorocksdb_tablepropertiescollectorfactory_name
+func gorocksdb_tablepropertiescollectorfactory_name(idx int) *C.char {
+	return tablePropertiesCollectorFactories.Get(idx).(tablePropertiesCollectorFactoryWrapper).name
+}
+
+//export gorocksdb_tablepropertiescollector_name
+func gorocksdb_tablepropertiescollector_name(handle C.uintptr_t) *C.char {
+	return C.CString(getTablePropertiesCollector(handle).Name())
+}
+
+//export gorocksdb_tablepropertiescollector_add_user_key
+func gorocksdb_tablepropertiescollector_add_user_key(handle C.uintptr_t, cKey *C.char, cKeyLen C.size_t, cValue *C.char, cValueLen C.size_t, cEntryType C.int, cSeq C.uint64_t, cFileSize C.uint64_t) *C.char {
+	key := charToByte(cKey, cKeyLen)
+	value := charToByte(cValue, cValueLen)
+	err := getTablePropertiesCollector(handle).AddUserKey(key, value, EntryType(cEntryType), uint64(cSeq), uint64(cFileSize))
+	return tablePropertiesCollectorError(err)
+}
+
+//export gorocksdb_tablepropertiescollector_finish
+func gorocksdb_tablepropertiescollector_finish(handle C.uintptr_t, cProps *C.gorocksdb_user_collected_properties_t) *C.char {
+	props, err := getTablePropertiesCollector(handle).Finish()
+	if err != nil {
+		return tablePropertiesCollectorError(err)
+	}
+	for key, value := range props {
+		cKey := C.CString(key)
+		cValue := C.CString(value)
+		C.gorocksdb_user_collected_properties_add(cProps, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)))
+		C.free(unsafe.Pointer(cKey))
+		C.free(unsafe.Pointer(cValue))
+	}
+	return nil
+}
+
+//export gorocksdb_tablepropertiescollector_destroy
+func gorocksdb_tablepropertiescollector_destroy(handle C.uintptr_t) {
+	tablePropertiesCollectors.Lock()
+	defer tablePropertiesCollectors.Unlock()
+	delete(tablePropertiesCollectors.collectors, uintptr(handle))
+}
@@ -0,0 +1,78 @@
+package gorocksdb
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+// tenantCountCollector counts the puts per tenant, the key prefix before
+// the first ':'.
+type tenantCountCollector struct {
+	counts map[string]int
+}
+
+func (c *tenantCountCollector) AddUserKey(key, value []byte, entryType EntryType, seq uint64, fileSize uint64) error {
+	if entryType != EntryPut {
+		return nil
+	}
+	if i := bytes.IndexByte(key, ':'); i >= 0 {
+		c.counts[string(key[:i])]++
+	}
+	return nil
+}
+
+func (c *tenantCountCollector) Finish() (map[string]string, error) {
+	props := make(map[string]string, len(c.counts))
+	for tenant, count := range c.counts {
+		props["tenant."+tenant] = strconv.Itoa(count)
+	}
+	return props, nil
+}
+
+func (c *tenantCountCollector) Name() string { return "tenant-count" }
+
+type tenantCountCollectorFactory struct{}
+
+func (tenantCountCollectorFactory) CreateTablePropertiesCollector(columnFamilyID uint32) TablePropertiesCollector {
+	return &tenantCountCollector{counts: make(map[string]int)}
+}
+
+func (tenantCountCollectorFactory) Name() string { return "tenant-count" }
+
+func TestTablePropertiesCollector(t *testing.T) {
+	db := newTestDB(t, "TestTablePropertiesCollector", func(opts *Options) {
+		opts.AddTablePropertiesCollectorFactory(tenantCountCollectorFactory{})
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	for _, key := range []string{"a:1", "a:2", "b:1"} {
+		ensure.Nil(t, db.Put(wo, []byte(key), []byte("value")))
+	}
+	ensure.Nil(t, db.Delete(wo, []byte("a:3")))
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+	all, err := db.GetPropertiesOfAllTables()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(all), 1)
+	for _, props := range all {
+		ensure.DeepEqual(t, props.NumEntries, uint64(4))
+		ensure.DeepEqual(t, props.NumDeletions, uint64(1))
+		ensure.True(t, props.DataSize > 0)
+		ensure.True(t, props.IndexSize > 0)
+		ensure.DeepEqual(t, props.ColumnFamilyName, "default")
+		ensure.DeepEqual(t, props.UserCollectedProperties["tenant.a"], "2")
+		ensure.DeepEqual(t, props.UserCollectedProperties["tenant.b"], "1")
+	}
+
+	inRange, err := db.GetPropertiesOfTablesInRange([]Range{{Start: []byte("a"), Limit: []byte("b")}})
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(inRange), 1)
+
+	inRange, err = db.GetPropertiesOfTablesInRange([]Range{{Start: []byte("x"), Limit: []byte("z")}})
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(inRange), 0)
+}
@@ -2,14 +2,13 @@ package gorocksdb
 
 // #include <stdlib.h>
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 
-import (
-	"errors"
-	"unsafe"
-)
+import "unsafe"
 
-// Transaction is used with TransactionDB for transaction support.
+// Transaction is used with TransactionDB and OptimisticTransactionDB for
+// transaction support.
 type Transaction struct {
 	c *C.rocksdb_transaction_t
 }
@@ -25,7 +24,7 @@ func (transaction *Transaction) Commit() error {
 	C.rocksdb_transaction_commit(transaction.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -36,7 +35,7 @@ func (transaction *Transaction) Rollback() error {
 	C.rocksdb_transaction_rollback(transaction.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -59,7 +58,7 @@ func (transaction *Transaction) RollbackToSavePoint() error {
 	C.rocksdb_transaction_rollback_to_savepoint(transaction.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -76,7 +75,7 @@ func (transaction *Transaction) Get(opts *ReadOptions, key []byte) (*Slice, erro
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return NewSlice(cValue, cValLen), nil
 }
@@ -93,7 +92,7 @@ func (transaction *Transaction) GetCF(opts *ReadOptions, cf *ColumnFamilyHandle,
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return NewSlice(cValue, cValLen), nil
 }
@@ -110,7 +109,25 @@ func (transaction *Transaction) GetForUpdate(opts *ReadOptions, key []byte) (*Sl
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return NewSlice(cValue, cValLen), nil
+}
+
+// GetForUpdateCF queries the data associated with the key in the column family
+// and puts an exclusive lock on the key from the database given this transaction.
+func (transaction *Transaction) GetForUpdateCF(opts *ReadOptions, cf *ColumnFamilyHandle, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_transaction_get_for_update_cf(
+		transaction.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cValLen, C.uchar(byte(1)) /*exclusive*/, &cErr,
+	)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return NewSlice(cValue, cValLen), nil
 }
@@ -127,7 +144,24 @@ func (transaction *Transaction) Put(key, value []byte) error {
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// PutCF writes data associated with a key to the column family in the transaction.
+func (transaction *Transaction) PutCF(cf *ColumnFamilyHandle, key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transaction_put_cf(
+		transaction.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr,
+	)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -142,7 +176,23 @@ func (transaction *Transaction) Merge(key, value []byte) error {
 	C.rocksdb_transaction_merge(transaction.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// MergeCF merges the data associated with the key in the column family
+// with the value given in the transaction.
+func (transaction *Transaction) MergeCF(cf *ColumnFamilyHandle, key, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_transaction_merge_cf(transaction.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -156,7 +206,48 @@ func (transaction *Transaction) Delete(key []byte) error {
 	C.rocksdb_transaction_delete(transaction.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// DeleteCF removes the data associated with the key in the column family from the transaction.
+func (transaction *Transaction) DeleteCF(cf *ColumnFamilyHandle, key []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+	)
+	C.rocksdb_transaction_delete_cf(transaction.c, cf.c, cKey, C.size_t(len(key)), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// SingleDelete removes the data associated with the key from the
+// transaction, see WriteBatch.SingleDelete for the restrictions of single
+// deletions.
+func (transaction *Transaction) SingleDelete(key []byte) error {
+	return transaction.singleDelete(nil, key)
+}
+
+// SingleDeleteCF removes the data associated with the key in the column
+// family from the transaction, see WriteBatch.SingleDelete for the
+// restrictions of single deletions.
+func (transaction *Transaction) SingleDeleteCF(cf *ColumnFamilyHandle, key []byte) error {
+	return transaction.singleDelete(cf.c, key)
+}
+
+func (transaction *Transaction) singleDelete(cf *C.rocksdb_column_family_handle_t, key []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+	)
+	C.gorocksdb_transaction_singledelete(transaction.c, cf, cKey, C.size_t(len(key)), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -168,6 +259,13 @@ func (transaction *Transaction) NewIterator(opts *ReadOptions) *Iterator {
 		unsafe.Pointer(C.rocksdb_transaction_create_iterator(transaction.c, opts.c)))
 }
 
+// NewIteratorCF returns an Iterator over the database and column family
+// that uses the ReadOptions given.
+func (transaction *Transaction) NewIteratorCF(opts *ReadOptions, cf *ColumnFamilyHandle) *Iterator {
+	return NewNativeIterator(
+		unsafe.Pointer(C.rocksdb_transaction_create_iterator_cf(transaction.c, opts.c, cf.c)))
+}
+
 // Destroy deallocates the transaction object.
 func (transaction *Transaction) Destroy() {
 	C.rocksdb_transaction_destroy(transaction.c)
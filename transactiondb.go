@@ -2,6 +2,7 @@ package gorocksdb
 
 // #include <stdlib.h>
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 import (
 	"errors"
@@ -31,7 +32,7 @@ func OpenTransactionDb(
 		opts.c, transactionDBOpts.c, cName, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return &TransactionDB{
 		name:              name,
@@ -41,6 +42,67 @@ func OpenTransactionDb(
 	}, nil
 }
 
+// OpenTransactionDbColumnFamilies opens a database with the specified column families.
+func OpenTransactionDbColumnFamilies(
+	opts *Options,
+	transactionDBOpts *TransactionDBOptions,
+	name string,
+	cfDescriptors []*ColumnFamilyDescriptor,
+) (*TransactionDB, []*ColumnFamilyHandle, error) {
+	numColumnFamilies := len(cfDescriptors)
+	if numColumnFamilies == 0 {
+		return nil, nil, errors.New("must provide the column family names and options")
+	}
+
+	var (
+		cNames = make([]*C.char, numColumnFamilies)
+		cOpts  = make([]*C.rocksdb_options_t, numColumnFamilies)
+	)
+	for i, s := range cfDescriptors {
+		cNames[i] = C.CString(s.Name)
+		cOpts[i] = s.Options.c
+	}
+	defer func() {
+		for _, s := range cNames {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	var (
+		cErr     *C.char
+		cName    = C.CString(name)
+		cHandles = make([]*C.rocksdb_column_family_handle_t, numColumnFamilies)
+	)
+	defer C.free(unsafe.Pointer(cName))
+
+	db := C.rocksdb_transactiondb_open_column_families(
+		opts.c,
+		transactionDBOpts.c,
+		cName,
+		C.int(numColumnFamilies),
+		&cNames[0],
+		&cOpts[0],
+		&cHandles[0],
+		&cErr,
+	)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, nil, newError(C.GoString(cErr))
+	}
+
+	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
+	for i, c := range cHandles {
+		cfHandles[i] = NewNativeColumnFamilyHandle(c, cfDescriptors[i].Name)
+	}
+
+	return &TransactionDB{
+		name:              name,
+		c:                 db,
+		opts:              opts,
+		transactionDBOpts: transactionDBOpts,
+	}, cfHandles, nil
+}
+
 // NewSnapshot creates a new snapshot of the database.
 func (db *TransactionDB) NewSnapshot() *Snapshot {
 	return NewNativeSnapshot(C.rocksdb_transactiondb_create_snapshot(db.c))
@@ -84,11 +146,25 @@ func (db *TransactionDB) Get(opts *ReadOptions, key []byte) (*Slice, error) {
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return NewSlice(cValue, cValLen), nil
 }
 
+// NewIterator returns an Iterator over the database that uses the
+// ReadOptions given.
+func (db *TransactionDB) NewIterator(opts *ReadOptions) *Iterator {
+	return NewNativeIterator(
+		unsafe.Pointer(C.rocksdb_transactiondb_create_iterator(db.c, opts.c)))
+}
+
+// NewIteratorCF returns an Iterator over the database and column family
+// that uses the ReadOptions given.
+func (db *TransactionDB) NewIteratorCF(opts *ReadOptions, cf *ColumnFamilyHandle) *Iterator {
+	return NewNativeIterator(
+		unsafe.Pointer(C.rocksdb_transactiondb_create_iterator_cf(db.c, opts.c, cf.c)))
+}
+
 // GetCF returns the data associated with the key from the database.
 func (db *TransactionDB) GetCF(opts *ReadOptions, cf *ColumnFamilyHandle, key []byte) (*Slice, error) {
 	var (
@@ -101,7 +177,7 @@ func (db *TransactionDB) GetCF(opts *ReadOptions, cf *ColumnFamilyHandle, key []
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 	return NewSlice(cValue, cValLen), nil
 }
@@ -118,7 +194,7 @@ func (db *TransactionDB) Put(opts *WriteOptions, key, value []byte) error {
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -135,7 +211,7 @@ func (db *TransactionDB) PutCF(opts *WriteOptions, cf *ColumnFamilyHandle, key,
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -149,7 +225,7 @@ func (db *TransactionDB) Delete(opts *WriteOptions, key []byte) error {
 	C.rocksdb_transactiondb_delete(db.c, opts.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -163,7 +239,7 @@ func (db *TransactionDB) DeleteCF(opts *WriteOptions, cf *ColumnFamilyHandle, ke
 	C.rocksdb_transactiondb_delete_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -181,11 +257,26 @@ func (db *TransactionDB) Merge(opts *WriteOptions, key, value []byte) error {
 	C.rocksdb_transactiondb_merge(db.c, opts.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
 
+// CreateColumnFamily create a new column family.
+func (db *TransactionDB) CreateColumnFamily(opts *Options, name string) (*ColumnFamilyHandle, error) {
+	var (
+		cErr  *C.char
+		cName = C.CString(name)
+	)
+	defer C.free(unsafe.Pointer(cName))
+	cHandle := C.rocksdb_transactiondb_create_column_family(db.c, opts.c, cName, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return NewNativeColumnFamilyHandle(cHandle, name), nil
+}
+
 // NewCheckpoint creates a new Checkpoint for this db.
 func (db *TransactionDB) NewCheckpoint() (*Checkpoint, error) {
 	var (
@@ -196,12 +287,32 @@ func (db *TransactionDB) NewCheckpoint() (*Checkpoint, error) {
 	)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return nil, errors.New(C.GoString(cErr))
+		return nil, newError(C.GoString(cErr))
 	}
 
 	return NewNativeCheckpoint(cCheckpoint), nil
 }
 
+// GetBaseDb returns the database as DB, for use with functions like
+// BackupEngine.CreateNewBackup which take a DB. Writes through the returned
+// DB still go through the transaction layer. The returned DB must be
+// released with CloseBaseDb and not with DB.Close, which would close the
+// database itself.
+func (db *TransactionDB) GetBaseDb() *DB {
+	return &DB{
+		c:    C.gorocksdb_transactiondb_get_base_db(db.c),
+		name: db.name,
+		opts: db.opts,
+	}
+}
+
+// CloseBaseDb releases a DB obtained through GetBaseDb. The database stays
+// open until the TransactionDB itself is closed.
+func (db *TransactionDB) CloseBaseDb(base *DB) {
+	C.gorocksdb_transactiondb_close_base_db(base.c)
+	base.c = nil
+}
+
 // Close closes the database.
 func (db *TransactionDB) Close() {
 	C.rocksdb_transactiondb_close(db.c)
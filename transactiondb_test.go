@@ -1,6 +1,7 @@
 package gorocksdb
 
 import (
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -93,6 +94,47 @@ func TestTransactionDBCRUD(t *testing.T) {
 
 }
 
+func TestTransactionSingleDelete(t *testing.T) {
+	db, cfh, cleanup := newTestTransactionDBCF(t, "TestTransactionSingleDelete", nil)
+	defer cleanup()
+
+	var (
+		givenKey1 = []byte("hello1")
+		givenKey2 = []byte("hello2")
+		givenVal  = []byte("world")
+		wo        = NewDefaultWriteOptions()
+		ro        = NewDefaultReadOptions()
+		to        = NewDefaultTransactionOptions()
+	)
+	ensure.Nil(t, db.Put(wo, givenKey1, givenVal))
+	ensure.Nil(t, db.PutCF(wo, cfh[1], givenKey2, givenVal))
+
+	txn := db.TransactionBegin(wo, to, nil)
+	defer txn.Destroy()
+	ensure.Nil(t, txn.SingleDelete(givenKey1))
+	ensure.Nil(t, txn.SingleDeleteCF(cfh[1], givenKey2))
+
+	// the single deletions are visible in the transaction only
+	v1, err := txn.Get(ro, givenKey1)
+	defer v1.Free()
+	ensure.Nil(t, err)
+	ensure.True(t, v1.Data() == nil)
+	v2, err := db.GetCF(ro, cfh[1], givenKey2)
+	defer v2.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v2.Data(), givenVal)
+
+	ensure.Nil(t, txn.Commit())
+	v3, err := db.Get(ro, givenKey1)
+	defer v3.Free()
+	ensure.Nil(t, err)
+	ensure.True(t, v3.Data() == nil)
+	v4, err := db.GetCF(ro, cfh[1], givenKey2)
+	defer v4.Free()
+	ensure.Nil(t, err)
+	ensure.True(t, v4.Data() == nil)
+}
+
 func TestTransactionDBGetForUpdate(t *testing.T) {
 	lockTimeoutMilliSec := int64(50)
 	applyOpts := func(opts *Options, transactionDBOpts *TransactionDBOptions) {
@@ -122,6 +164,30 @@ func TestTransactionDBGetForUpdate(t *testing.T) {
 	}
 }
 
+func TestTransactionDBLockTimeoutError(t *testing.T) {
+	applyOpts := func(opts *Options, transactionDBOpts *TransactionDBOptions) {
+		transactionDBOpts.SetTransactionLockTimeout(50)
+	}
+	db := newTestTransactionDB(t, "TestTransactionDBLockTimeoutError", applyOpts)
+	defer db.Close()
+
+	var (
+		givenKey = []byte("hello")
+		wo       = NewDefaultWriteOptions()
+		ro       = NewDefaultReadOptions()
+	)
+	txn := db.TransactionBegin(wo, NewDefaultTransactionOptions(), nil)
+	defer txn.Destroy()
+	v, err := txn.GetForUpdate(ro, givenKey)
+	defer v.Free()
+	ensure.Nil(t, err)
+
+	err = db.Put(wo, givenKey, []byte("world"))
+	ensure.True(t, errors.Is(err, ErrTimedOut))
+	ensure.True(t, errors.Is(err, ErrLockTimeout))
+	ensure.False(t, errors.Is(err, ErrTransactionConflict))
+}
+
 func newTestTransactionDB(t *testing.T, name string, applyOpts func(opts *Options, transactionDBOpts *TransactionDBOptions)) *TransactionDB {
 	dir, err := ioutil.TempDir("", "gorockstransactiondb-"+name)
 	ensure.Nil(t, err)
@@ -137,3 +203,152 @@ func newTestTransactionDB(t *testing.T, name string, applyOpts func(opts *Option
 
 	return db
 }
+
+func TestTransactionDBColumnFamilyCreate(t *testing.T) {
+	db := newTestTransactionDB(t, "TestTransactionDBColumnFamilyCreate", nil)
+	defer db.Close()
+
+	opts := NewDefaultOptions()
+	cf, err := db.CreateColumnFamily(opts, "guide")
+	ensure.Nil(t, err)
+	defer cf.Destroy()
+	ensure.DeepEqual(t, cf.Name(), "guide")
+
+	var (
+		givenKey = []byte("hello")
+		givenVal = []byte("world")
+		wo       = NewDefaultWriteOptions()
+		ro       = NewDefaultReadOptions()
+	)
+	ensure.Nil(t, db.PutCF(wo, cf, givenKey, givenVal))
+	v, err := db.GetCF(ro, cf, givenKey)
+	defer v.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v.Data(), givenVal)
+}
+
+func TestTransactionDBColumnFamilyCRUD(t *testing.T) {
+	db, cfh, cleanup := newTestTransactionDBCF(t, "TestTransactionDBColumnFamilyCRUD", nil)
+	defer cleanup()
+
+	var (
+		givenKey0 = []byte("hello0")
+		givenVal0 = []byte("world0")
+		givenKey1 = []byte("hello1")
+		givenVal1 = []byte("world1")
+		wo        = NewDefaultWriteOptions()
+		ro        = NewDefaultReadOptions()
+		to        = NewDefaultTransactionOptions()
+	)
+
+	txn := db.TransactionBegin(wo, to, nil)
+	defer txn.Destroy()
+	ensure.Nil(t, txn.PutCF(cfh[0], givenKey0, givenVal0))
+	ensure.Nil(t, txn.PutCF(cfh[1], givenKey1, givenVal1))
+
+	v0, err := txn.GetCF(ro, cfh[0], givenKey0)
+	defer v0.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v0.Data(), givenVal0)
+
+	// the key was written to the other column family only
+	v1, err := txn.GetCF(ro, cfh[0], givenKey1)
+	ensure.Nil(t, err)
+	ensure.True(t, v1.Data() == nil)
+	ensure.Nil(t, txn.Commit())
+
+	v2, err := db.GetCF(ro, cfh[1], givenKey1)
+	defer v2.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v2.Data(), givenVal1)
+
+	// iterate over the column family
+	txn2 := db.TransactionBegin(wo, to, nil)
+	defer txn2.Destroy()
+	iter := txn2.NewIteratorCF(ro, cfh[1])
+	defer iter.Close()
+	var numFound int
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		ensure.DeepEqual(t, iter.Key().Data(), givenKey1)
+		ensure.DeepEqual(t, iter.Value().Data(), givenVal1)
+		numFound++
+	}
+	ensure.Nil(t, iter.Err())
+	ensure.DeepEqual(t, numFound, 1)
+
+	// delete
+	ensure.Nil(t, txn2.DeleteCF(cfh[1], givenKey1))
+	ensure.Nil(t, txn2.Commit())
+	v3, err := db.GetCF(ro, cfh[1], givenKey1)
+	ensure.Nil(t, err)
+	ensure.True(t, v3.Data() == nil)
+}
+
+func TestTransactionDBColumnFamilyGetForUpdate(t *testing.T) {
+	lockTimeoutMilliSec := int64(50)
+	applyOpts := func(opts *Options, transactionDBOpts *TransactionDBOptions) {
+		transactionDBOpts.SetTransactionLockTimeout(lockTimeoutMilliSec)
+	}
+	db, cfh, cleanup := newTestTransactionDBCF(t, "TestTransactionDBColumnFamilyGetForUpdate", applyOpts)
+	defer cleanup()
+
+	var (
+		givenKey = []byte("hello")
+		givenVal = []byte("world")
+		wo       = NewDefaultWriteOptions()
+		ro       = NewDefaultReadOptions()
+		to       = NewDefaultTransactionOptions()
+	)
+
+	txn := db.TransactionBegin(wo, to, nil)
+	defer txn.Destroy()
+
+	v, err := txn.GetForUpdateCF(ro, cfh[1], givenKey)
+	defer v.Free()
+	ensure.Nil(t, err)
+
+	// the lock is held on the column family the key was read from
+	ensure.Nil(t, db.PutCF(wo, cfh[0], givenKey, givenVal))
+	// expect lock timeout error to be thrown
+	if err := db.PutCF(wo, cfh[1], givenKey, givenVal); err == nil {
+		t.Error("expect locktime out error, got nil error")
+	}
+}
+
+func newTestTransactionDBCF(t *testing.T, name string, applyOpts func(opts *Options, transactionDBOpts *TransactionDBOptions)) (db *TransactionDB, cfh []*ColumnFamilyHandle, cleanup func()) {
+	dir, err := ioutil.TempDir("", "gorockstransactiondb-"+name)
+	ensure.Nil(t, err)
+
+	givenNames := []string{"default", "guide"}
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetCreateIfMissing(true)
+	transactionDBOpts := NewDefaultTransactionDBOptions()
+	if applyOpts != nil {
+		applyOpts(opts, transactionDBOpts)
+	}
+
+	cfDescriptors := []*ColumnFamilyDescriptor{
+		&ColumnFamilyDescriptor{
+			Name:    givenNames[0],
+			Options: opts,
+		},
+		&ColumnFamilyDescriptor{
+			Name:    givenNames[1],
+			Options: opts,
+		},
+	}
+
+	db, cfh, err = OpenTransactionDbColumnFamilies(opts, transactionDBOpts, dir, cfDescriptors)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(cfh), 2)
+	ensure.DeepEqual(t, cfDescriptors[0].Name, cfh[0].Name())
+	ensure.DeepEqual(t, cfDescriptors[1].Name, cfh[1].Name())
+	cleanup = func() {
+		for _, cf := range cfh {
+			cf.Destroy()
+		}
+		db.Close()
+	}
+	return db, cfh, cleanup
+}
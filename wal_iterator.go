@@ -0,0 +1,77 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+import "C"
+import "unsafe"
+
+// WALIterator iterates over the updates recorded in the write ahead log,
+// created by DB.GetUpdatesSince. Each update is returned as the WriteBatch
+// that was written, together with the sequence number of its first record.
+//
+// For example:
+//
+//	iter, err := db.GetUpdatesSince(seq)
+//	if err != nil {
+//		return err
+//	}
+//	defer iter.Destroy()
+//
+//	for ; iter.Valid(); iter.Next() {
+//		batch, seq := iter.GetBatch()
+//		records := batch.NewIterator()
+//		for records.Next() {
+//			fmt.Printf("Seq: %d Record: %v\n", seq, records.Record())
+//		}
+//		batch.Destroy()
+//	}
+//
+//	if err := iter.Err(); err != nil {
+//		return err
+//	}
+type WALIterator struct {
+	c *C.rocksdb_wal_iterator_t
+}
+
+// NewNativeWALIterator creates a WALIterator object.
+func NewNativeWALIterator(c unsafe.Pointer) *WALIterator {
+	return &WALIterator{(*C.rocksdb_wal_iterator_t)(c)}
+}
+
+// Valid returns false when the iterator has reached the end of the log
+// or an error occurred.
+func (iter *WALIterator) Valid() bool {
+	return C.rocksdb_wal_iter_valid(iter.c) != 0
+}
+
+// Next moves the iterator to the next update in the log.
+func (iter *WALIterator) Next() {
+	C.rocksdb_wal_iter_next(iter.c)
+}
+
+// Err returns nil if no errors happened during iteration, or the actual
+// error otherwise.
+func (iter *WALIterator) Err() error {
+	var cErr *C.char
+	C.rocksdb_wal_iter_status(iter.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// GetBatch returns the update the iterator currently holds and the sequence
+// number of its first record. The WriteBatch is owned by the caller and must
+// be destroyed after use.
+func (iter *WALIterator) GetBatch() (*WriteBatch, uint64) {
+	var cSeq C.uint64_t
+	cBatch := C.rocksdb_wal_iter_get_batch(iter.c, &cSeq)
+	return NewNativeWriteBatch(cBatch), uint64(cSeq)
+}
+
+// Destroy deallocates the WALIterator object.
+func (iter *WALIterator) Destroy() {
+	C.rocksdb_wal_iter_destroy(iter.c)
+	iter.c = nil
+}
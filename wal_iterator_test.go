@@ -0,0 +1,59 @@
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestWALIterator(t *testing.T) {
+	db := newTestDB(t, "TestWALIterator", func(opts *Options) {
+		opts.SetWALTtlSeconds(3600)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+
+	startSeq := db.GetLatestSequenceNumber()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val1")))
+	wb := NewWriteBatch()
+	defer wb.Destroy()
+	wb.Put([]byte("key2"), []byte("val2"))
+	wb.Delete([]byte("key1"))
+	ensure.Nil(t, db.Write(wo, wb))
+	ensure.DeepEqual(t, db.GetLatestSequenceNumber(), startSeq+3)
+
+	iter, err := db.GetUpdatesSince(startSeq + 1)
+	ensure.Nil(t, err)
+	defer iter.Destroy()
+
+	var (
+		seqs    []uint64
+		records []WriteBatchRecord
+	)
+	for ; iter.Valid(); iter.Next() {
+		batch, seq := iter.GetBatch()
+		seqs = append(seqs, seq)
+		batchIter := batch.NewIterator()
+		for batchIter.Next() {
+			record := *batchIter.Record()
+			record.Key = append([]byte(nil), record.Key...)
+			record.Value = append([]byte(nil), record.Value...)
+			records = append(records, record)
+		}
+		ensure.Nil(t, batchIter.Error())
+		batch.Destroy()
+	}
+	ensure.Nil(t, iter.Err())
+
+	ensure.DeepEqual(t, seqs, []uint64{startSeq + 1, startSeq + 2})
+	ensure.DeepEqual(t, len(records), 3)
+	ensure.DeepEqual(t, records[0].Type, WriteBatchValueRecord)
+	ensure.DeepEqual(t, records[0].Key, []byte("key1"))
+	ensure.DeepEqual(t, records[0].Value, []byte("val1"))
+	ensure.DeepEqual(t, records[1].Type, WriteBatchValueRecord)
+	ensure.DeepEqual(t, records[1].Key, []byte("key2"))
+	ensure.DeepEqual(t, records[2].Type, WriteBatchDeletionRecord)
+	ensure.DeepEqual(t, records[2].Key, []byte("key1"))
+}
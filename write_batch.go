@@ -160,6 +160,23 @@ func (wb *WriteBatch) DeleteV(keys [][]byte) {
 	)
 }
 
+// SingleDelete queues a single deletion of the data at key. A single
+// deletion only removes the most recent version of the key and cancels out
+// with it during compaction. It must only be used for keys which were
+// written once since their last deletion and never merged or overwritten.
+func (wb *WriteBatch) SingleDelete(key []byte) {
+	cKey := byteToChar(key)
+	C.rocksdb_writebatch_singledelete(wb.c, cKey, C.size_t(len(key)))
+}
+
+// SingleDeleteV queues a single deletion of the data at each key, see
+// SingleDelete.
+func (wb *WriteBatch) SingleDeleteV(keys [][]byte) {
+	for _, key := range keys {
+		wb.SingleDelete(key)
+	}
+}
+
 // DeleteRange queues a deletion range of the data at key.
 func (wb *WriteBatch) DeleteRange(begin, end []byte) {
 	var (
@@ -189,6 +206,21 @@ func (wb *WriteBatch) DeleteVCF(cf *ColumnFamilyHandle, keys [][]byte) {
 	)
 }
 
+// SingleDeleteCF queues a single deletion of the data at key in a column
+// family, see SingleDelete.
+func (wb *WriteBatch) SingleDeleteCF(cf *ColumnFamilyHandle, key []byte) {
+	cKey := byteToChar(key)
+	C.rocksdb_writebatch_singledelete_cf(wb.c, cf.c, cKey, C.size_t(len(key)))
+}
+
+// SingleDeleteVCF queues a single deletion of the data at each key in a
+// column family, see SingleDelete.
+func (wb *WriteBatch) SingleDeleteVCF(cf *ColumnFamilyHandle, keys [][]byte) {
+	for _, key := range keys {
+		wb.SingleDeleteCF(cf, key)
+	}
+}
+
 // DeleteRangeCF queues a deletion range of the data at key in a column family.
 func (wb *WriteBatch) DeleteRangeCF(cf *ColumnFamilyHandle, begin, end []byte) {
 	var (
@@ -227,7 +259,7 @@ func (wb *WriteBatch) RollBackToSavePoint() error {
 	C.rocksdb_writebatch_rollback_to_save_point(wb.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -514,6 +546,21 @@ func (wb *WriteBatchWithIndex) Delete(key []byte) {
 	C.rocksdb_writebatch_wi_delete(wb.c, cKey, C.size_t(len(key)))
 }
 
+// SingleDelete queues a single deletion of the data at key, see
+// WriteBatch.SingleDelete.
+func (wb *WriteBatchWithIndex) SingleDelete(key []byte) {
+	cKey := byteToChar(key)
+	C.rocksdb_writebatch_wi_singledelete(wb.c, cKey, C.size_t(len(key)))
+}
+
+// SingleDeleteV queues a single deletion of the data at each key, see
+// WriteBatch.SingleDelete.
+func (wb *WriteBatchWithIndex) SingleDeleteV(keys [][]byte) {
+	for _, key := range keys {
+		wb.SingleDelete(key)
+	}
+}
+
 // DeleteRange queues a deletion range of the data at key.
 func (wb *WriteBatchWithIndex) DeleteRange(begin, end []byte) {
 	var (
@@ -529,6 +576,21 @@ func (wb *WriteBatchWithIndex) DeleteCF(cf *ColumnFamilyHandle, key []byte) {
 	C.rocksdb_writebatch_wi_delete_cf(wb.c, cf.c, cKey, C.size_t(len(key)))
 }
 
+// SingleDeleteCF queues a single deletion of the data at key in a column
+// family, see WriteBatch.SingleDelete.
+func (wb *WriteBatchWithIndex) SingleDeleteCF(cf *ColumnFamilyHandle, key []byte) {
+	cKey := byteToChar(key)
+	C.rocksdb_writebatch_wi_singledelete_cf(wb.c, cf.c, cKey, C.size_t(len(key)))
+}
+
+// SingleDeleteVCF queues a single deletion of the data at each key in a
+// column family, see WriteBatch.SingleDelete.
+func (wb *WriteBatchWithIndex) SingleDeleteVCF(cf *ColumnFamilyHandle, keys [][]byte) {
+	for _, key := range keys {
+		wb.SingleDeleteCF(cf, key)
+	}
+}
+
 // DeleteRangeCF queues a deletion range of the data at key in a column family.
 func (wb *WriteBatchWithIndex) DeleteRangeCF(cf *ColumnFamilyHandle, begin, end []byte) {
 	var (
@@ -567,11 +629,103 @@ func (wb *WriteBatchWithIndex) RollBackToSavePoint() error {
 	C.rocksdb_writebatch_wi_rollback_to_save_point(wb.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newError(C.GoString(cErr))
 	}
 	return nil
 }
 
+// GetFromBatch returns the data associated with the key from the batch only.
+// opts must be the options of the database the batch is written to. It
+// returns a nil value if the key was deleted in the batch or is not in the
+// batch, and an error if the key was merged in the batch.
+func (wb *WriteBatchWithIndex) GetFromBatch(opts *Options, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_writebatch_wi_get_from_batch(wb.c, opts.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return NewSlice(cValue, cValLen), nil
+}
+
+// GetFromBatchCF returns the data associated with the key in a column family
+// from the batch only, see GetFromBatch.
+func (wb *WriteBatchWithIndex) GetFromBatchCF(opts *Options, cf *ColumnFamilyHandle, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_writebatch_wi_get_from_batch_cf(wb.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return NewSlice(cValue, cValLen), nil
+}
+
+// GetFromBatchAndDB returns the data associated with the key from the batch
+// and the database, as if the batch was written to the database.
+func (wb *WriteBatchWithIndex) GetFromBatchAndDB(db *DB, opts *ReadOptions, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_writebatch_wi_get_from_batch_and_db(wb.c, db.c, opts.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return NewSlice(cValue, cValLen), nil
+}
+
+// GetFromBatchAndDBCF returns the data associated with the key in a column
+// family from the batch and the database, see GetFromBatchAndDB.
+func (wb *WriteBatchWithIndex) GetFromBatchAndDBCF(db *DB, opts *ReadOptions, cf *ColumnFamilyHandle, key []byte) (*Slice, error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_writebatch_wi_get_from_batch_and_db_cf(wb.c, db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cValLen, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, newError(C.GoString(cErr))
+	}
+	return NewSlice(cValue, cValLen), nil
+}
+
+// NewIteratorWithBase returns an Iterator over the default column family of
+// baseIter, usually an iterator over the database, merged with the updates
+// in the batch. The returned iterator takes ownership of baseIter, which
+// must not be used afterwards; closing it has no effect. The batch must not
+// be modified while the iterator is used.
+func (wb *WriteBatchWithIndex) NewIteratorWithBase(baseIter *Iterator) *Iterator {
+	cIter := C.rocksdb_writebatch_wi_create_iterator_with_base(wb.c, baseIter.c)
+	return wb.newIteratorWithBase(cIter, baseIter)
+}
+
+// NewIteratorWithBaseCF returns an Iterator over the column family of
+// baseIter merged with the updates to that column family in the batch, see
+// NewIteratorWithBase.
+func (wb *WriteBatchWithIndex) NewIteratorWithBaseCF(baseIter *Iterator, cf *ColumnFamilyHandle) *Iterator {
+	cIter := C.rocksdb_writebatch_wi_create_iterator_with_base_cf(wb.c, baseIter.c, cf.c)
+	return wb.newIteratorWithBase(cIter, baseIter)
+}
+
+func (wb *WriteBatchWithIndex) newIteratorWithBase(cIter *C.rocksdb_iterator_t, baseIter *Iterator) *Iterator {
+	// the C wrapper of the base iterator was freed, its RocksDB iterator
+	// is owned by the new iterator
+	iter := &Iterator{c: cIter, ctx: baseIter.ctx}
+	baseIter.c = nil
+	return iter
+}
+
 // Count returns the number of updates in the batch.
 func (wb *WriteBatchWithIndex) Count() int {
 	return int(C.rocksdb_writebatch_wi_count(wb.c))
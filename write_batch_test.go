@@ -89,3 +89,215 @@ func TestWriteBatchIterator(t *testing.T) {
 	// there shouldn't be any left
 	ensure.False(t, iter.Next())
 }
+
+func TestWriteBatchSingleDelete(t *testing.T) {
+	db := newTestDB(t, "TestWriteBatchSingleDelete", nil)
+	defer db.Close()
+
+	var (
+		givenKey1 = []byte("key1")
+		givenKey2 = []byte("key2")
+		givenKey3 = []byte("key3")
+		givenVal  = []byte("val")
+	)
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, givenKey1, givenVal))
+	ensure.Nil(t, db.Put(wo, givenKey2, givenVal))
+	ensure.Nil(t, db.Put(wo, givenKey3, givenVal))
+
+	// create and fill the write batch
+	wb := NewWriteBatch()
+	defer wb.Destroy()
+	wb.SingleDeleteV([][]byte{givenKey1, givenKey2})
+	ensure.DeepEqual(t, wb.Count(), 2)
+
+	iter := wb.NewIterator()
+	ensure.True(t, iter.Next())
+	record := iter.Record()
+	ensure.DeepEqual(t, record.Type, WriteBatchSingleDeletionRecord)
+	ensure.DeepEqual(t, record.Key, givenKey1)
+	ensure.True(t, iter.Next())
+	ensure.False(t, iter.Next())
+
+	// perform the batch
+	ensure.Nil(t, db.Write(wo, wb))
+	ensure.Nil(t, db.SingleDelete(wo, givenKey3))
+
+	// check changes
+	ro := NewDefaultReadOptions()
+	for _, key := range [][]byte{givenKey1, givenKey2, givenKey3} {
+		v, err := db.GetBytes(ro, key)
+		ensure.Nil(t, err)
+		ensure.True(t, v == nil)
+	}
+}
+
+func TestWriteBatchSingleDeleteCF(t *testing.T) {
+	db, cfh, cleanup := newTestDBCF(t, "TestWriteBatchSingleDeleteCF")
+	defer cleanup()
+
+	var (
+		givenKey1 = []byte("key1")
+		givenKey2 = []byte("key2")
+		givenKey3 = []byte("key3")
+		givenVal  = []byte("val")
+		wo        = NewDefaultWriteOptions()
+		ro        = NewDefaultReadOptions()
+	)
+	ensure.Nil(t, db.Put(wo, givenKey1, givenVal))
+	for _, key := range [][]byte{givenKey1, givenKey2, givenKey3} {
+		ensure.Nil(t, db.PutCF(wo, cfh[1], key, givenVal))
+	}
+
+	// create and fill the write batch
+	wb := NewWriteBatch()
+	defer wb.Destroy()
+	wb.SingleDeleteCF(cfh[1], givenKey1)
+	wb.SingleDeleteVCF(cfh[1], [][]byte{givenKey2, givenKey3})
+	ensure.DeepEqual(t, wb.Count(), 3)
+
+	iter := wb.NewIterator()
+	for _, key := range [][]byte{givenKey1, givenKey2, givenKey3} {
+		ensure.True(t, iter.Next())
+		record := iter.Record()
+		ensure.DeepEqual(t, record.Type, WriteBatchCFSingleDeletionRecord)
+		ensure.DeepEqual(t, record.CF, 1)
+		ensure.DeepEqual(t, record.Key, key)
+	}
+	ensure.False(t, iter.Next())
+
+	// perform the batch
+	ensure.Nil(t, db.Write(wo, wb))
+	for _, key := range [][]byte{givenKey1, givenKey2, givenKey3} {
+		v, err := db.GetCF(ro, cfh[1], key)
+		ensure.Nil(t, err)
+		ensure.True(t, v.Data() == nil)
+		v.Free()
+	}
+
+	// the default column family is unchanged
+	v, err := db.GetBytes(ro, givenKey1)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v, givenVal)
+}
+
+func TestWriteBatchWithIndex(t *testing.T) {
+	db := newTestDB(t, "TestWriteBatchWithIndex", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("db1")))
+	ensure.Nil(t, db.Put(wo, []byte("key3"), []byte("db3")))
+
+	// create and fill the write batch
+	wb := NewWriteBatchWithIndex(0, 1)
+	defer wb.Destroy()
+	wb.Put([]byte("key2"), []byte("wb2"))
+	wb.Delete([]byte("key3"))
+
+	// read from the batch only
+	v1, err := wb.GetFromBatch(db.opts, []byte("key2"))
+	defer v1.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1.Data(), []byte("wb2"))
+	v2, err := wb.GetFromBatch(db.opts, []byte("key1"))
+	defer v2.Free()
+	ensure.Nil(t, err)
+	ensure.True(t, v2.Data() == nil)
+
+	// read from the batch and the database
+	ro := NewDefaultReadOptions()
+	v3, err := wb.GetFromBatchAndDB(db, ro, []byte("key1"))
+	defer v3.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v3.Data(), []byte("db1"))
+	v4, err := wb.GetFromBatchAndDB(db, ro, []byte("key3"))
+	defer v4.Free()
+	ensure.Nil(t, err)
+	ensure.True(t, v4.Data() == nil)
+
+	// iterate over the batch and the database
+	baseIter := db.NewIterator(ro)
+	iter := wb.NewIteratorWithBase(baseIter)
+	// the base iterator is owned by iter, closing it has no effect
+	baseIter.Close()
+	var keys, values []string
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key().Data()))
+		values = append(values, string(iter.Value().Data()))
+	}
+	ensure.Nil(t, iter.Err())
+	iter.Close()
+	ensure.DeepEqual(t, keys, []string{"key1", "key2"})
+	ensure.DeepEqual(t, values, []string{"db1", "wb2"})
+
+	// perform the batch
+	ensure.Nil(t, db.WriteWithIndex(wo, wb))
+	v5, err := db.GetBytes(ro, []byte("key2"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v5, []byte("wb2"))
+	v6, err := db.GetBytes(ro, []byte("key3"))
+	ensure.Nil(t, err)
+	ensure.True(t, v6 == nil)
+}
+
+func TestWriteBatchWithIndexSingleDelete(t *testing.T) {
+	db, cfh, cleanup := newTestDBCF(t, "TestWriteBatchWithIndexSingleDelete")
+	defer cleanup()
+
+	var (
+		givenKey1 = []byte("key1")
+		givenKey2 = []byte("key2")
+		givenKey3 = []byte("key3")
+		givenKey4 = []byte("key4")
+		givenVal  = []byte("val")
+		wo        = NewDefaultWriteOptions()
+		ro        = NewDefaultReadOptions()
+	)
+	ensure.Nil(t, db.Put(wo, givenKey1, givenVal))
+	ensure.Nil(t, db.Put(wo, givenKey2, givenVal))
+	ensure.Nil(t, db.PutCF(wo, cfh[1], givenKey3, givenVal))
+	ensure.Nil(t, db.PutCF(wo, cfh[1], givenKey4, givenVal))
+
+	// create and fill the write batch
+	wb := NewWriteBatchWithIndex(0, 1)
+	defer wb.Destroy()
+	wb.SingleDelete(givenKey1)
+	wb.SingleDeleteV([][]byte{givenKey2})
+	wb.SingleDeleteCF(cfh[1], givenKey3)
+	wb.SingleDeleteVCF(cfh[1], [][]byte{givenKey4})
+	ensure.DeepEqual(t, wb.Count(), 4)
+
+	// the batch holds the single deletion records
+	batch := WriteBatchFrom(wb.Data())
+	defer batch.Destroy()
+	iter := batch.NewIterator()
+	for _, key := range [][]byte{givenKey1, givenKey2} {
+		ensure.True(t, iter.Next())
+		record := iter.Record()
+		ensure.DeepEqual(t, record.Type, WriteBatchSingleDeletionRecord)
+		ensure.DeepEqual(t, record.Key, key)
+	}
+	for _, key := range [][]byte{givenKey3, givenKey4} {
+		ensure.True(t, iter.Next())
+		record := iter.Record()
+		ensure.DeepEqual(t, record.Type, WriteBatchCFSingleDeletionRecord)
+		ensure.DeepEqual(t, record.CF, 1)
+		ensure.DeepEqual(t, record.Key, key)
+	}
+	ensure.False(t, iter.Next())
+
+	// perform the batch
+	ensure.Nil(t, db.Write(wo, batch))
+	for _, key := range [][]byte{givenKey1, givenKey2} {
+		v, err := db.GetBytes(ro, key)
+		ensure.Nil(t, err)
+		ensure.True(t, v == nil)
+	}
+	for _, key := range [][]byte{givenKey3, givenKey4} {
+		v, err := db.GetCF(ro, cfh[1], key)
+		ensure.Nil(t, err)
+		ensure.True(t, v.Data() == nil)
+		v.Free()
+	}
+}